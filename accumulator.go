@@ -0,0 +1,156 @@
+package udecimal
+
+import "math/big"
+
+// Accumulator is an exact running total of decimals.
+//
+// The total is kept as a 256-bit two's complement integer scaled by 10^19 (maxPrec),
+// so adding a decimal never rescales the total and doesn't allocate memory.
+// It can hold sums of about 2^64 values with 128-bit coefficients before falling back to big.Int.
+//
+// The zero value is an empty total ready to use.
+// An Accumulator is not safe for concurrent use. To aggregate from several goroutines,
+// use one Accumulator per goroutine and combine them with [Accumulator.Merge].
+type Accumulator struct {
+	// sum = total * 10^maxPrec
+	sum u256
+
+	// fallback when the total doesn't fit into 256 bits or a decimal with a big.Int coefficient is added,
+	// sum is not used when big is not nil
+	big *big.Int
+}
+
+// Add adds d to the total.
+func (a *Accumulator) Add(d Decimal) {
+	a.add(d.neg, d)
+}
+
+// Sub subtracts d from the total.
+func (a *Accumulator) Sub(d Decimal) {
+	a.add(!d.neg, d)
+}
+
+// add adds d to the total, where the sign of d is replaced by neg
+func (a *Accumulator) add(neg bool, d Decimal) {
+	if a.big == nil && !d.coef.overflow() {
+		v := d.coef.u128.MulToU256(pow10[maxPrec-d.prec])
+		if a.addU256(neg, v) {
+			return
+		}
+	}
+
+	// overflow, fallback to big.Int
+	v := d.coef.GetBig()
+	v.Mul(v, pow10[maxPrec-d.prec].ToBigInt())
+
+	a.toBig()
+	if neg {
+		a.big.Sub(a.big, v)
+	} else {
+		a.big.Add(a.big, v)
+	}
+}
+
+// addU256 adds v (or -v if neg is true) to a.sum, where 0 <= v < 2^255.
+// Returns false without modifying a.sum if the result overflows.
+func (a *Accumulator) addU256(neg bool, v u256) bool {
+	var s u256
+	if neg {
+		s = a.sum.sub(v)
+
+		// a - v with v >= 0 overflows only if a < 0 and the result is not
+		if a.sum.isNeg() && !s.isNeg() {
+			return false
+		}
+	} else {
+		s = a.sum.add(v)
+
+		// a + v with v >= 0 overflows only if a >= 0 and the result is not
+		if !a.sum.isNeg() && s.isNeg() {
+			return false
+		}
+	}
+
+	a.sum = s
+	return true
+}
+
+// Merge adds the total of b to the total of a. b is not modified.
+func (a *Accumulator) Merge(b Accumulator) {
+	if a.big == nil && b.big == nil {
+		s := a.sum.add(b.sum)
+
+		// adding two numbers overflows only if they have the same sign and the result has a different one
+		if a.sum.isNeg() != b.sum.isNeg() || s.isNeg() == a.sum.isNeg() {
+			a.sum = s
+			return
+		}
+	}
+
+	a.toBig()
+	a.big.Add(a.big, b.bigTotal())
+}
+
+// Reset sets the total to zero.
+func (a *Accumulator) Reset() {
+	*a = Accumulator{}
+}
+
+// Result returns the total rounded to prec digits after the decimal point using the given rounding mode.
+// If prec > defaultPrec, defaultPrec is used.
+//
+// Panics if mode is not a valid [RoundingMode].
+func (a Accumulator) Result(prec uint8, mode RoundingMode) Decimal {
+	prec = min(prec, defaultPrec)
+
+	if a.big == nil {
+		neg, v := a.sum.isNeg(), a.sum
+		if neg {
+			v = u256{}.sub(v)
+		}
+
+		if v.carry.IsZero() {
+			d := newDecimal(neg, bintFromU128(u128FromHiLo(v.hi, v.lo)), maxPrec)
+			return d.Round(prec, mode).trimTrailingZeros()
+		}
+
+		if prec < maxPrec {
+			factor := pow10[maxPrec-prec]
+			q, r, err := v.fastQuo(factor)
+			if err == nil {
+				q, err = roundQuoU128(neg, q, r, factor, mode)
+				if err == nil {
+					return newDecimal(neg, bintFromU128(q), prec).trimTrailingZeros()
+				}
+			}
+		}
+	}
+
+	// overflow, fallback to big.Int
+	total := a.bigTotal()
+	neg := total.Sign() < 0
+
+	d := newDecimal(neg, bintFromBigInt(total.Abs(total)), maxPrec)
+	return d.Round(prec, mode).trimTrailingZeros()
+}
+
+// toBig switches a to the big.Int representation
+func (a *Accumulator) toBig() {
+	if a.big == nil {
+		a.big = a.bigTotal()
+	}
+}
+
+// bigTotal returns a copy of the scaled total as *big.Int
+func (a Accumulator) bigTotal() *big.Int {
+	if a.big != nil {
+		return new(big.Int).Set(a.big)
+	}
+
+	if !a.sum.isNeg() {
+		return a.sum.ToBigInt()
+	}
+
+	v := u256{}.sub(a.sum).ToBigInt()
+	return v.Neg(v)
+}
@@ -0,0 +1,165 @@
+package udecimal
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccumulator(t *testing.T) {
+	testcases := []struct {
+		add, sub []string
+		prec     uint8
+		mode     RoundingMode
+		want     string
+	}{
+		{nil, nil, 19, RoundHalfEven, "0"},
+		{[]string{"1.5", "2.5"}, nil, 19, RoundHalfEven, "4"},
+		{[]string{"1.5", "2.5"}, []string{"4"}, 19, RoundHalfEven, "0"},
+		{[]string{"0.1", "0.2"}, []string{"0.3", "0.3"}, 19, RoundHalfEven, "-0.3"},
+		{[]string{"1.005", "1.0000000000000000001"}, nil, 2, RoundHalfEven, "2.01"},
+		{[]string{"1.005"}, nil, 2, RoundHalfEven, "1"},
+		{[]string{"1.005"}, nil, 2, RoundHalfUp, "1.01"},
+		{[]string{"-1.005"}, nil, 2, RoundFloor, "-1.01"},
+		{[]string{"-1.005"}, nil, 2, RoundCeiling, "-1"},
+		{[]string{"0.0000000000000000001"}, nil, 30, RoundHalfEven, "0.0000000000000000001"},
+		{[]string{maxU128Str, maxU128Str}, nil, 19, RoundHalfEven, "680564733841876926926749214863536422910"},
+		{[]string{maxU128Str, maxU128Str}, []string{"0.0000000000000000001"}, 19, RoundHalfEven, "680564733841876926926749214863536422909.9999999999999999999"},
+		{[]string{maxU128Str, maxU128Str}, []string{"0.0000000000000000001"}, 0, RoundHalfEven, "680564733841876926926749214863536422910"},
+		{[]string{"-" + maxU128Str, "-" + maxU128Str}, []string{"0.5"}, 0, RoundHalfEven, "-680564733841876926926749214863536422910"},
+		{[]string{"-" + maxU128Str, "-" + maxU128Str}, []string{"0.5"}, 0, RoundHalfUp, "-680564733841876926926749214863536422911"},
+		{
+			[]string{"1234567890123456789012345678901234567890123456789012345678901234567890"},
+			[]string{"0.1"},
+			19, RoundHalfEven,
+			"1234567890123456789012345678901234567890123456789012345678901234567889.9",
+		},
+		{
+			[]string{"1234567890123456789012345678901234567890123456789012345678901234567890"},
+			[]string{"1234567890123456789012345678901234567890123456789012345678901234567890", "0.1"},
+			19, RoundHalfEven,
+			"-0.1",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%v-%v_%d_%s", tc.add, tc.sub, tc.prec, tc.mode), func(t *testing.T) {
+			var acc Accumulator
+			for _, s := range tc.add {
+				acc.Add(MustParse(s))
+			}
+
+			for _, s := range tc.sub {
+				acc.Sub(MustParse(s))
+			}
+
+			require.Equal(t, tc.want, acc.Result(tc.prec, tc.mode).String())
+		})
+	}
+}
+
+func TestAccumulatorOverflow256(t *testing.T) {
+	// 2^250 * 10^19 overflows 256 bits after a few additions
+	huge := MustParse("1809251394333065553493296640760748560207343510400633813116524750123642650624")
+
+	var acc Accumulator
+	want := decimal.Zero
+	for range 40 {
+		acc.Add(huge)
+		want = want.Add(decimal.RequireFromString(huge.String()))
+	}
+
+	require.NotNil(t, acc.big)
+	require.Equal(t, want.String(), acc.Result(19, RoundHalfEven).String())
+
+	for range 40 {
+		acc.Sub(huge)
+	}
+
+	acc.Add(MustParse("-1.25"))
+	require.Equal(t, "-1.25", acc.Result(19, RoundHalfEven).String())
+}
+
+func TestAccumulatorRandom(t *testing.T) {
+	values := make([]Decimal, 10000)
+	for i := range values {
+		//nolint:gosec // it's fine to use math/rand in tests
+		coef, prec := rand.Int64(), uint8(rand.IntN(20))
+		if rand.IntN(2) == 0 {
+			coef = -coef
+		}
+
+		values[i] = MustFromInt64(coef, prec)
+	}
+
+	// split the values among several accumulators and merge them
+	var (
+		parts [4]Accumulator
+		total Accumulator
+		want  = decimal.Zero
+	)
+
+	for i, v := range values {
+		if i%3 == 0 {
+			parts[i%4].Sub(v)
+			want = want.Sub(decimal.RequireFromString(v.String()))
+		} else {
+			parts[i%4].Add(v)
+			want = want.Add(decimal.RequireFromString(v.String()))
+		}
+	}
+
+	for _, p := range parts {
+		total.Merge(p)
+	}
+
+	require.Equal(t, want.String(), total.Result(19, RoundHalfEven).String())
+	require.Equal(t, want.Round(2).String(), total.Result(2, RoundHalfUp).String())
+
+	total.Reset()
+	require.Equal(t, "0", total.Result(19, RoundHalfEven).String())
+}
+
+func TestAccumulatorMergeBig(t *testing.T) {
+	huge := MustParse("1234567890123456789012345678901234567890123456789012345678901234567890")
+
+	var a, b Accumulator
+	a.Add(MustParse("1.5"))
+	b.Add(huge)
+	b.Sub(MustParse("0.25"))
+
+	a.Merge(b)
+	require.Equal(t, "1234567890123456789012345678901234567890123456789012345678901234567891.25", a.Result(19, RoundHalfEven).String())
+
+	// b is not modified
+	require.Equal(t, "1234567890123456789012345678901234567890123456789012345678901234567889.75", b.Result(19, RoundHalfEven).String())
+
+	var c Accumulator
+	c.Add(MustParse("-2"))
+	b.Merge(c)
+	require.Equal(t, "1234567890123456789012345678901234567890123456789012345678901234567887.75", b.Result(19, RoundHalfEven).String())
+}
+
+func TestAccumulatorNoAlloc(t *testing.T) {
+	a := MustParse("12345678901234567890.1234567890123456789")
+	b := MustParse("-1234567890.123456789")
+
+	var acc, other Accumulator
+	other.Sub(a)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		acc.Add(a)
+		acc.Add(a)
+		acc.Sub(b)
+		acc.Merge(other)
+		_ = acc.Result(2, RoundHalfEven)
+		acc.Merge(other)
+		acc.Add(b)
+		_ = acc.Result(19, RoundHalfEven)
+	})
+
+	require.Zero(t, allocs)
+}
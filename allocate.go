@@ -0,0 +1,228 @@
+package udecimal
+
+import (
+	"fmt"
+	"slices"
+)
+
+var (
+	// ErrAllocateNoRatios is returned when allocating an amount across an empty list of ratios or zero parts
+	ErrAllocateNoRatios = fmt.Errorf("can't allocate: no ratios")
+
+	// ErrAllocateInvalidRatios is returned when a ratio is negative or all ratios are zero
+	ErrAllocateInvalidRatios = fmt.Errorf("can't allocate: ratios must be non-negative and have a positive sum")
+
+	// ErrAllocateAmountPrec is returned when the amount has more digits after the decimal point than the parts
+	ErrAllocateAmountPrec = fmt.Errorf("can't allocate: amount has more digits after the decimal point than prec")
+)
+
+// AllocationStrategy specifies which parts receive the units left over
+// after every part is rounded toward zero.
+type AllocationStrategy uint8
+
+const (
+	// AllocateLargestRemainder gives the leftover units to the parts with the largest discarded remainders
+	// (Hamilton's method), ties go to the earlier part.
+	AllocateLargestRemainder AllocationStrategy = iota
+
+	// AllocateToFirst gives the leftover units to the first parts with a non-zero remainder.
+	AllocateToFirst
+
+	// AllocateToLast gives the leftover units to the last parts with a non-zero remainder.
+	AllocateToLast
+)
+
+// Allocate splits amount into parts proportional to ratios, each with at most prec digits after the decimal point.
+// The parts always sum exactly to amount. The units left over after rounding every part toward zero
+// are distributed with [AllocateLargestRemainder].
+// If prec > defaultPrec, defaultPrec is used.
+//
+// Returns error if:
+//   - ratios is empty ([ErrAllocateNoRatios])
+//   - a ratio is negative or all ratios are zero ([ErrAllocateInvalidRatios])
+//   - amount has more than prec digits after the decimal point ([ErrAllocateAmountPrec])
+//
+// Example:
+//
+//	Allocate(100, [1, 1, 1], 2) = [33.34, 33.33, 33.33]
+//	Allocate(0.05, [3, 7], 2) = [0.02, 0.03]
+func Allocate(amount Decimal, ratios []Decimal, prec uint8) ([]Decimal, error) {
+	return AllocateWithStrategy(amount, ratios, prec, AllocateLargestRemainder)
+}
+
+// AllocateWithStrategy is similar to [Allocate] but the leftover units are distributed with the given strategy.
+//
+// Panics if strategy is not a valid [AllocationStrategy].
+func AllocateWithStrategy(amount Decimal, ratios []Decimal, prec uint8, strategy AllocationStrategy) ([]Decimal, error) {
+	if len(ratios) == 0 {
+		return nil, ErrAllocateNoRatios
+	}
+
+	prec = min(prec, defaultPrec)
+
+	// work with integers: amount in units of 10^(-prec) and ratios scaled by 10^(max ratio prec)
+	units := amount.Abs().Mul(newDecimal(false, bintFromU128(pow10[prec]), 0))
+	if units.prec != 0 && units.Trunc(0).Cmp(units) != 0 {
+		return nil, ErrAllocateAmountPrec
+	}
+
+	units = units.Trunc(0)
+
+	var ratioPrec uint8
+	for _, r := range ratios {
+		if r.neg {
+			return nil, ErrAllocateInvalidRatios
+		}
+
+		ratioPrec = max(ratioPrec, r.prec)
+	}
+
+	factor := newDecimal(false, bintFromU128(pow10[ratioPrec]), 0)
+
+	weights := make([]Decimal, len(ratios))
+	total := Zero
+	for i, r := range ratios {
+		weights[i] = r.Mul(factor).Trunc(0)
+		total = total.Add(weights[i])
+	}
+
+	if total.IsZero() {
+		return nil, ErrAllocateInvalidRatios
+	}
+
+	// part[i] = floor(units * weights[i] / total), rems[i] is the discarded remainder
+	parts := make([]Decimal, len(ratios))
+	rems := make([]Decimal, len(ratios))
+	left := units
+	for i, w := range weights {
+		q, r, err := units.Mul(w).QuoRem(total)
+		if err != nil {
+			return nil, err
+		}
+
+		parts[i], rems[i] = q, r
+		left = left.Sub(q)
+	}
+
+	// left < len(ratios), so it always fits into int
+	n, err := left.Int64()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range leftoverOrder(rems, strategy)[:n] {
+		parts[i] = parts[i].Add(One)
+	}
+
+	unit := newDecimal(amount.neg, bintFromU64(1), prec)
+	for i := range parts {
+		parts[i] = parts[i].Mul(unit)
+	}
+
+	return parts, nil
+}
+
+// Split splits amount into n equal parts with at most prec digits after the decimal point.
+// The parts always sum exactly to amount, the leftover units go to the first parts.
+// If prec > defaultPrec, defaultPrec is used.
+//
+// Returns error if:
+//   - n is zero ([ErrAllocateNoRatios])
+//   - amount has more than prec digits after the decimal point ([ErrAllocateAmountPrec])
+//
+// Example:
+//
+//	Split(100, 3, 2) = [33.34, 33.33, 33.33]
+//	Split(-0.1, 4, 2) = [-0.03, -0.03, -0.02, -0.02]
+func Split(amount Decimal, n int, prec uint8) ([]Decimal, error) {
+	if n <= 0 {
+		return nil, ErrAllocateNoRatios
+	}
+
+	ratios := make([]Decimal, n)
+	for i := range ratios {
+		ratios[i] = One
+	}
+
+	return Allocate(amount, ratios, prec)
+}
+
+// RoundLineItems rounds items to prec digits after the decimal point so that the rounded items
+// sum exactly to the rounded total, i.e. Round(sum(items), prec, mode).
+// Every item is first rounded toward negative infinity, then the missing units go to the items
+// with the largest discarded remainders, ties go to the earlier item.
+// If prec >= d.Prec() for every item, the items are returned unchanged.
+// If prec > defaultPrec, defaultPrec is used.
+//
+// Panics if mode is not a valid [RoundingMode].
+//
+// Example:
+//
+//	RoundLineItems([0.333, 0.333, 0.334], 2, RoundHalfEven) = [0.33, 0.33, 0.34]
+//	RoundLineItems([1.005, 1.005, 1.005], 2, RoundHalfEven) = [1.01, 1.01, 1] (total 3.015 -> 3.02)
+func RoundLineItems(items []Decimal, prec uint8, mode RoundingMode) []Decimal {
+	prec = min(prec, defaultPrec)
+
+	sum := Zero
+	for _, d := range items {
+		sum = sum.Add(d)
+	}
+
+	target := sum.Round(prec, mode)
+
+	rounded := make([]Decimal, len(items))
+	rems := make([]Decimal, len(items))
+	floorSum := Zero
+	for i, d := range items {
+		rounded[i] = d.Round(prec, RoundFloor)
+		rems[i] = d.Sub(rounded[i])
+		floorSum = floorSum.Add(rounded[i])
+	}
+
+	// number of missing units, 0 <= n <= len(items)
+	unit := newDecimal(false, bintFromU64(1), prec)
+	q, _, err := target.Sub(floorSum).QuoRem(unit)
+	if err != nil {
+		// unreachable, unit is not zero
+		panic(err)
+	}
+
+	n, err := q.Int64()
+	if err != nil {
+		// unreachable, n <= len(items)
+		panic(err)
+	}
+
+	for _, i := range leftoverOrder(rems, AllocateLargestRemainder)[:n] {
+		rounded[i] = rounded[i].Add(unit)
+	}
+
+	return rounded
+}
+
+// leftoverOrder returns the indexes of the parts in the order they receive the leftover units.
+// Parts without a remainder are already exact and never receive a unit.
+func leftoverOrder(rems []Decimal, strategy AllocationStrategy) []int {
+	order := make([]int, 0, len(rems))
+	for i := range rems {
+		if !rems[i].IsZero() {
+			order = append(order, i)
+		}
+	}
+
+	switch strategy {
+	case AllocateLargestRemainder:
+		// stable sort keeps the earlier part first on ties
+		slices.SortStableFunc(order, func(a, b int) int {
+			return rems[b].Cmp(rems[a])
+		})
+	case AllocateToFirst:
+		// already in order
+	case AllocateToLast:
+		slices.Reverse(order)
+	default:
+		panic(fmt.Sprintf("can't allocate: invalid allocation strategy %d", strategy))
+	}
+
+	return order
+}
@@ -0,0 +1,197 @@
+package udecimal
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func parseAll(ss ...string) []Decimal {
+	ds := make([]Decimal, len(ss))
+	for i, s := range ss {
+		ds[i] = MustParse(s)
+	}
+
+	return ds
+}
+
+func decimalsString(ds []Decimal) []string {
+	ss := make([]string, len(ds))
+	for i, d := range ds {
+		ss[i] = d.String()
+	}
+
+	return ss
+}
+
+func TestAllocate(t *testing.T) {
+	testcases := []struct {
+		amount   string
+		ratios   []string
+		prec     uint8
+		strategy AllocationStrategy
+		want     []string
+		wantErr  error
+	}{
+		{"100", nil, 2, AllocateLargestRemainder, nil, ErrAllocateNoRatios},
+		{"100", []string{"1", "-1"}, 2, AllocateLargestRemainder, nil, ErrAllocateInvalidRatios},
+		{"100", []string{"0", "0"}, 2, AllocateLargestRemainder, nil, ErrAllocateInvalidRatios},
+		{"1.005", []string{"1", "1"}, 2, AllocateLargestRemainder, nil, ErrAllocateAmountPrec},
+		{"1.000", []string{"1", "1"}, 2, AllocateLargestRemainder, []string{"0.5", "0.5"}, nil},
+		{"100", []string{"1", "1", "1"}, 2, AllocateLargestRemainder, []string{"33.34", "33.33", "33.33"}, nil},
+		{"-100", []string{"1", "1", "1"}, 2, AllocateLargestRemainder, []string{"-33.34", "-33.33", "-33.33"}, nil},
+		{"0", []string{"1", "2"}, 2, AllocateLargestRemainder, []string{"0", "0"}, nil},
+		{"0.05", []string{"3", "7"}, 2, AllocateLargestRemainder, []string{"0.02", "0.03"}, nil},
+		{"1", []string{"0.6", "0.3", "0.1"}, 1, AllocateLargestRemainder, []string{"0.6", "0.3", "0.1"}, nil},
+		{"10", []string{"0.333", "0.333", "0.334"}, 0, AllocateLargestRemainder, []string{"3", "3", "4"}, nil},
+		{"10", []string{"1", "1", "1"}, 0, AllocateLargestRemainder, []string{"4", "3", "3"}, nil},
+		{"10", []string{"1", "1", "1"}, 0, AllocateToFirst, []string{"4", "3", "3"}, nil},
+		{"10", []string{"1", "1", "1"}, 0, AllocateToLast, []string{"3", "3", "4"}, nil},
+		{"10", []string{"0", "1", "1", "1", "0"}, 0, AllocateToFirst, []string{"0", "4", "3", "3", "0"}, nil},
+		{"10", []string{"0", "1", "1", "1", "0"}, 0, AllocateToLast, []string{"0", "3", "3", "4", "0"}, nil},
+		{"10", []string{"6", "1", "1", "1", "1", "2"}, 0, AllocateToFirst, []string{"5", "1", "1", "1", "1", "1"}, nil},
+		{"10", []string{"6", "1", "1", "1", "1", "2"}, 0, AllocateToLast, []string{"5", "0", "1", "1", "1", "2"}, nil},
+		{"11", []string{"1", "2", "3"}, 0, AllocateLargestRemainder, []string{"2", "4", "5"}, nil},
+		{"11", []string{"1", "2", "3"}, 0, AllocateToFirst, []string{"2", "4", "5"}, nil},
+		{"11", []string{"1", "2", "3"}, 0, AllocateToLast, []string{"1", "4", "6"}, nil},
+		{"0.0000000000000000001", []string{"1", "1"}, 30, AllocateLargestRemainder, []string{"0.0000000000000000001", "0"}, nil},
+		{
+			"340282366920938463463374607431768211455", []string{"1", "1", "1"}, 2, AllocateLargestRemainder,
+			[]string{"113427455640312821154458202477256070485", "113427455640312821154458202477256070485", "113427455640312821154458202477256070485"},
+			nil,
+		},
+		{
+			"1234567890123456789012345678901234567890.12", []string{"0.0000000000000000001", "0.0000000000000000002"}, 2, AllocateLargestRemainder,
+			[]string{"411522630041152263004115226300411522630.04", "823045260082304526008230452600823045260.08"},
+			nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%v_%d_%d", tc.amount, tc.ratios, tc.prec, tc.strategy), func(t *testing.T) {
+			parts, err := AllocateWithStrategy(MustParse(tc.amount), parseAll(tc.ratios...), tc.prec, tc.strategy)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, decimalsString(parts))
+		})
+	}
+
+	require.PanicsWithValue(t, "can't allocate: invalid allocation strategy 3", func() {
+		_, _ = AllocateWithStrategy(One, parseAll("1", "1", "1"), 0, AllocationStrategy(3))
+	})
+}
+
+func TestSplit(t *testing.T) {
+	testcases := []struct {
+		amount  string
+		n       int
+		prec    uint8
+		want    []string
+		wantErr error
+	}{
+		{"100", 0, 2, nil, ErrAllocateNoRatios},
+		{"100", -1, 2, nil, ErrAllocateNoRatios},
+		{"0.001", 2, 2, nil, ErrAllocateAmountPrec},
+		{"100", 1, 2, []string{"100"}, nil},
+		{"100", 3, 2, []string{"33.34", "33.33", "33.33"}, nil},
+		{"-0.1", 4, 2, []string{"-0.03", "-0.03", "-0.02", "-0.02"}, nil},
+		{"0.02", 3, 2, []string{"0.01", "0.01", "0"}, nil},
+		{"7", 2, 0, []string{"4", "3"}, nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%d_%d", tc.amount, tc.n, tc.prec), func(t *testing.T) {
+			parts, err := Split(MustParse(tc.amount), tc.n, tc.prec)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, decimalsString(parts))
+		})
+	}
+}
+
+func TestAllocateRandom(t *testing.T) {
+	for range 2000 {
+		//nolint:gosec // it's fine to use math/rand in tests
+		prec := uint8(rand.IntN(5))
+		amount := MustFromInt64(rand.Int64N(1_000_000_000)-500_000_000, prec)
+
+		ratios := make([]Decimal, 1+rand.IntN(10))
+		for i := range ratios {
+			//nolint:gosec // it's fine to use math/rand in tests
+			ratios[i] = MustFromInt64(rand.Int64N(10000), uint8(rand.IntN(4)))
+		}
+
+		ratios[0] = ratios[0].Add(One)
+
+		for _, strategy := range []AllocationStrategy{AllocateLargestRemainder, AllocateToFirst, AllocateToLast} {
+			parts, err := AllocateWithStrategy(amount, ratios, prec, strategy)
+			require.NoError(t, err)
+
+			// parts sum exactly to amount and each part is within one unit of its exact share
+			total, sumRatios := Zero, Zero
+			for _, r := range ratios {
+				sumRatios = sumRatios.Add(r)
+			}
+
+			unit := MustFromInt64(1, prec)
+			for i, p := range parts {
+				require.LessOrEqual(t, p.PrecUint(), prec)
+				total = total.Add(p)
+
+				exact, err := amount.MulDivRound(ratios[i], sumRatios, maxPrec, RoundHalfEven)
+				require.NoError(t, err)
+				require.Negative(t, p.Sub(exact).Abs().Cmp(unit), "amount=%s ratios=%v parts=%v", amount, ratios, parts)
+			}
+
+			require.Equal(t, amount.String(), total.String())
+		}
+	}
+}
+
+func TestRoundLineItems(t *testing.T) {
+	testcases := []struct {
+		items []string
+		prec  uint8
+		mode  RoundingMode
+		want  []string
+	}{
+		{nil, 2, RoundHalfEven, []string{}},
+		{[]string{"1.23", "4.5"}, 2, RoundHalfEven, []string{"1.23", "4.5"}},
+		{[]string{"0.333", "0.333", "0.334"}, 2, RoundHalfEven, []string{"0.33", "0.33", "0.34"}},
+		{[]string{"1.005", "1.005", "1.005"}, 2, RoundHalfEven, []string{"1.01", "1.01", "1"}},
+		{[]string{"1.005", "1.005", "1.005"}, 2, RoundDown, []string{"1.01", "1", "1"}},
+		{[]string{"1.005", "1.005", "1.005"}, 2, RoundUp, []string{"1.01", "1.01", "1"}},
+		{[]string{"-1.005", "-1.005", "-1.005"}, 2, RoundHalfEven, []string{"-1", "-1.01", "-1.01"}},
+		{[]string{"-1.005", "-1.005", "-1.005"}, 2, RoundFloor, []string{"-1", "-1.01", "-1.01"}},
+		{[]string{"2.4", "2.4", "2.2"}, 0, RoundHalfEven, []string{"3", "2", "2"}},
+		{[]string{"10.25", "-3.125", "0.001"}, 1, RoundHalfUp, []string{"10.2", "-3.1", "0"}},
+		{[]string{"0.0000000000000000001"}, 30, RoundHalfEven, []string{"0.0000000000000000001"}},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%v_%d_%s", tc.items, tc.prec, tc.mode), func(t *testing.T) {
+			items := parseAll(tc.items...)
+			got := RoundLineItems(items, tc.prec, tc.mode)
+			require.Equal(t, tc.want, decimalsString(got))
+
+			// the rounded items add up to the rounded total
+			sum, roundedSum := Zero, Zero
+			for i := range items {
+				sum = sum.Add(items[i])
+				roundedSum = roundedSum.Add(got[i])
+			}
+
+			require.Equal(t, sum.Round(min(tc.prec, defaultPrec), tc.mode).String(), roundedSum.String())
+		})
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"strings"
 )
 
-var (
-	defaultParseMode = ParseModeError
-)
+var defaultParseMode = ParseModeError
 
+// SetDefaultParseMode changes the default parse mode for decimal numbers in the package.
+// It's not safe for concurrent use, use [Context.WithParseMode] to parse with a different mode locally.
+//
+// Panics if mode is not a valid [ParseMode]
 func SetDefaultParseMode(mode ParseMode) {
 	switch mode {
 	case ParseModeError, ParseModeTrunc:
@@ -97,19 +99,176 @@ func errInvalidFormat(s []byte) error {
 	return fmt.Errorf("%w: can't parse '%s'", ErrInvalidFormat, s)
 }
 
-func parseBint(s []byte) (bool, bint, uint8, error) {
+// maxParseExponent caps the exponent of scientific notation while parsing it, larger exponents
+// always make the number longer than maxStrLen
+const maxParseExponent = 1 << 20
+
+// parseBintExp is parseBint that also accepts scientific notation when allowExp is true.
+// The number is rewritten without exponent, e.g. 1.23e4 -> 12300 and 5E-3 -> 0.005, then parsed by parseBint.
+// maxLen is the maximum length of s and of the number without exponent.
+func parseBintExp(s []byte, precLimit uint8, mode ParseMode, allowExp bool, maxLen int) (bool, bint, uint8, error) {
+	if !allowExp || len(s) > maxLen {
+		return parseBintMaxLen(s, precLimit, mode, maxLen)
+	}
+
+	i := bytes.IndexAny(s, "eE")
+	if i == -1 {
+		return parseBintMaxLen(s, precLimit, mode, maxLen)
+	}
+
+	plain, err := expandExponent(s, i, precLimit, mode, maxLen)
+	if err != nil {
+		return false, bint{}, 0, err
+	}
+
+	return parseBintMaxLen(plain, precLimit, mode, maxLen)
+}
+
+// expandExponent rewrites s, which has an exponent at index i, as a plain decimal string.
+// Digits after the decimal point that exceed precLimit are rejected or truncated depending on mode,
+// trailing zeros are dropped first.
+func expandExponent(s []byte, i int, precLimit uint8, mode ParseMode, maxLen int) ([]byte, error) {
+	mant := s[:i]
+
+	var neg bool
+	if len(mant) > 0 && (mant[0] == '-' || mant[0] == '+') {
+		neg = mant[0] == '-'
+		mant = mant[1:]
+	}
+
+	intPart, fracPart := mant, []byte(nil)
+	if dot := bytes.IndexByte(mant, '.'); dot != -1 {
+		intPart, fracPart = mant[:dot], mant[dot+1:]
+
+		// prevent "1.e5"
+		if len(fracPart) == 0 {
+			return nil, errInvalidFormat(s)
+		}
+	}
+
+	// prevent "e5", ".5e5" and "-e5"
+	if len(intPart) == 0 || !isDigits(intPart) || !isDigits(fracPart) {
+		return nil, errInvalidFormat(s)
+	}
+
+	exp, ok := parseExponent(s[i+1:])
+	if !ok {
+		return nil, errInvalidFormat(s)
+	}
+
+	// the number is 0.digits * 10^point
+	digits := make([]byte, 0, len(intPart)+len(fracPart))
+	digits = append(digits, intPart...)
+	digits = append(digits, fracPart...)
+	point := len(intPart) + exp
+
+	for len(digits) > 0 && digits[0] == '0' {
+		digits = digits[1:]
+		point--
+	}
+
+	for len(digits)-point > int(precLimit) && len(digits) > 0 && digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+	}
+
+	if len(digits) == 0 {
+		return []byte{'0'}, nil
+	}
+
+	if len(digits)-point > int(precLimit) {
+		if mode == ParseModeError {
+			return nil, ErrPrecOutOfRange
+		}
+
+		// ParseModeTrunc, invalid modes are reported by parseBint
+		digits = digits[:max(point+int(precLimit), 0)]
+		if len(digits) == 0 {
+			return []byte{'0'}, nil
+		}
+	}
+
+	// sign + integer part + dot + fractional part
+	if 1+max(point, 1)+1+max(len(digits)-point, 0) > maxLen {
+		return nil, ErrExponentOutOfRange
+	}
+
+	plain := make([]byte, 0, maxLen)
+	if neg {
+		plain = append(plain, '-')
+	}
+
+	switch {
+	case point <= 0:
+		plain = append(plain, '0', '.')
+		plain = append(plain, bytes.Repeat([]byte{'0'}, -point)...)
+		plain = append(plain, digits...)
+	case point >= len(digits):
+		plain = append(plain, digits...)
+		plain = append(plain, bytes.Repeat([]byte{'0'}, point-len(digits))...)
+	default:
+		plain = append(plain, digits[:point]...)
+		plain = append(plain, '.')
+		plain = append(plain, digits[point:]...)
+	}
+
+	return plain, nil
+}
+
+// parseExponent parses [+-]d+, values larger than maxParseExponent are capped
+func parseExponent(s []byte) (int, bool) {
+	neg := false
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	if len(s) == 0 || !isDigits(s) {
+		return 0, false
+	}
+
+	exp := 0
+	for _, c := range s {
+		exp = min(exp*10+int(c-'0'), maxParseExponent)
+	}
+
+	if neg {
+		return -exp, true
+	}
+
+	return exp, true
+}
+
+func isDigits(s []byte) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseBint parses s into a coefficient and precision.
+// precLimit is the maximum number of digits after the decimal point and mode decides
+// whether exceeding digits are rejected or truncated.
+func parseBint(s []byte, precLimit uint8, mode ParseMode) (bool, bint, uint8, error) {
+	return parseBintMaxLen(s, precLimit, mode, maxStrLen)
+}
+
+// parseBintMaxLen is parseBint with a custom maximum length of s
+func parseBintMaxLen(s []byte, precLimit uint8, mode ParseMode, maxLen int) (bool, bint, uint8, error) {
 	if len(s) == 0 {
 		return false, bint{}, 0, ErrEmptyString
 	}
 
-	if len(s) > maxStrLen {
+	if len(s) > maxLen {
 		return false, bint{}, 0, ErrMaxStrLen
 	}
 
 	// if s has less than 41 characters, it can fit into u128
 	// 41 chars = maxLen(u128) + dot + sign = 39 + 1 + 1
 	if len(s) <= 41 {
-		neg, bint, prec, err := parseBintFromU128(s)
+		neg, bint, prec, err := parseBintFromU128(s, precLimit, mode)
 		if err == nil || err != errOverflow {
 			return neg, bint, prec, err
 		}
@@ -161,18 +320,23 @@ func parseBint(s []byte) (bool, bint, uint8, error) {
 		return false, bint{}, 0, errInvalidFormat(s)
 	default:
 		prec = vLen - pIndex - 1
-		switch defaultParseMode {
+		switch mode {
 		case ParseModeError:
-			if prec > int(defaultPrec) {
+			if prec > int(precLimit) {
 				return false, bint{}, 0, ErrPrecOutOfRange
 			}
 		case ParseModeTrunc:
-			if prec > int(defaultPrec) {
-				value = value[:pIndex+1+int(defaultPrec)]
-				prec = int(defaultPrec)
+			if prec > int(precLimit) {
+				// the dropped digits must still be valid
+				if !isDigits(value[pIndex+1+int(precLimit):]) {
+					return false, bint{}, 0, errInvalidFormat(s)
+				}
+
+				value = value[:pIndex+1+int(precLimit)]
+				prec = int(precLimit)
 			}
 		default:
-			return false, bint{}, 0, fmt.Errorf("invalid parse mode: %d. Make sure to use SetParseMode with a valid value", defaultParseMode)
+			return false, bint{}, 0, fmt.Errorf("invalid parse mode: %d. Make sure to use SetParseMode with a valid value", mode)
 		}
 
 		b := strings.Builder{}
@@ -205,7 +369,7 @@ func parseBint(s []byte) (bool, bint, uint8, error) {
 	return neg, bintFromBigInt(dValue), uint8(prec), nil
 }
 
-func parseBintFromU128(s []byte) (bool, bint, uint8, error) {
+func parseBintFromU128(s []byte, precLimit uint8, mode ParseMode) (bool, bint, uint8, error) {
 	width := len(s)
 
 	var (
@@ -242,9 +406,9 @@ func parseBintFromU128(s []byte) (bool, bint, uint8, error) {
 	)
 
 	if len(s[pos:]) <= maxDigitU64 {
-		coef, prec, err = parseSmallToU128(s[pos:])
+		coef, prec, err = parseSmallToU128(s[pos:], precLimit, mode)
 	} else {
-		coef, prec, err = parseLargeToU128(s[pos:])
+		coef, prec, err = parseLargeToU128(s[pos:], precLimit, mode)
 	}
 
 	if err == ErrInvalidFormat {
@@ -254,7 +418,7 @@ func parseBintFromU128(s []byte) (bool, bint, uint8, error) {
 	return neg, bint{u128: coef}, prec, err
 }
 
-func parseSmallToU128(s []byte) (u128, uint8, error) {
+func parseSmallToU128(s []byte, precLimit uint8, mode ParseMode) (u128, uint8, error) {
 	var (
 		coef uint64
 		prec uint8
@@ -275,8 +439,18 @@ func parseSmallToU128(s []byte) (u128, uint8, error) {
 				return u128{}, 0, ErrInvalidFormat
 			}
 
-			if prec > defaultPrec {
-				return u128{}, 0, ErrPrecOutOfRange
+			if prec > precLimit {
+				if mode != ParseModeTrunc {
+					return u128{}, 0, ErrPrecOutOfRange
+				}
+
+				// drop the exceeded digits once they are validated, the loop stops at the new end of s
+				if !isDigits(s[i+1+int(precLimit):]) {
+					return u128{}, 0, ErrInvalidFormat
+				}
+
+				s = s[:i+1+int(precLimit)]
+				prec = precLimit
 			}
 
 			continue
@@ -296,7 +470,7 @@ func parseSmallToU128(s []byte) (u128, uint8, error) {
 	return u128{lo: coef}, prec, nil
 }
 
-func parseLargeToU128(s []byte) (u128, uint8, error) {
+func parseLargeToU128(s []byte, precLimit uint8, mode ParseMode) (u128, uint8, error) {
 	// find '.' position
 	l := len(s)
 	pos := bytes.IndexByte(s, '.')
@@ -318,18 +492,23 @@ func parseLargeToU128(s []byte) (u128, uint8, error) {
 	// now 0 < pos < l-1
 	//nolint:gosec // l < maxStrLen, so 0 < l-pos-1 < 256, can be safely converted to uint8
 	prec := uint8(l - pos - 1)
-	switch defaultParseMode {
+	switch mode {
 	case ParseModeError:
-		if prec > defaultPrec {
+		if prec > precLimit {
 			return u128{}, 0, ErrPrecOutOfRange
 		}
 	case ParseModeTrunc:
-		if prec > defaultPrec {
-			s = s[:pos+1+int(defaultPrec)]
-			prec = defaultPrec
+		if prec > precLimit {
+			// the dropped digits must still be valid
+			if !isDigits(s[pos+1+int(precLimit):]) {
+				return u128{}, 0, ErrInvalidFormat
+			}
+
+			s = s[:pos+1+int(precLimit)]
+			prec = precLimit
 		}
 	default:
-		return u128{}, 0, fmt.Errorf("invalid parse mode: %d. Make sure to use SetParseMode with a valid value", defaultParseMode)
+		return u128{}, 0, fmt.Errorf("invalid parse mode: %d. Make sure to use SetParseMode with a valid value", mode)
 	}
 
 	// number has a decimal point, split into 2 parts: integer and fraction
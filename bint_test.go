@@ -85,3 +85,89 @@ func TestInvalidParseMode(t *testing.T) {
 		})
 	}
 }
+
+func TestParseExponent(t *testing.T) {
+	// disabled by default
+	_, err := Parse("1.23e4")
+	require.ErrorIs(t, err, ErrInvalidFormat)
+
+	ctx := DefaultContext().WithParseExponent(true)
+
+	testcases := []struct {
+		input   string
+		want    string
+		prec    uint8
+		wantErr error
+	}{
+		{"1.23e4", "12300", 0, nil},
+		{"5E-3", "0.005", 3, nil},
+		{"-1.5e+2", "-150", 0, nil},
+		{"+2.5E0", "2.5", 1, nil},
+		{"123e-2", "1.23", 2, nil},
+		{"1.50e-1", "0.15", 3, nil},
+		{"0.000123e2", "0.0123", 4, nil},
+		{"1e-19", "0.0000000000000000001", 19, nil},
+		{"1.50000e-17", "0.000000000000000015", 19, nil},
+		{"-1.23456789012345678901234e5", "-123456.789012345678901234", 18, nil},
+		{"1e38", "100000000000000000000000000000000000000", 0, nil},
+		{"1.5e40", "15000000000000000000000000000000000000000", 0, nil},
+		{"0e999999999999", "0", 0, nil},
+		{"-0.0e-5", "0", 0, nil},
+		{"0012.5e1", "125", 0, nil},
+		{"123", "123", 0, nil},
+		{"-1.5", "-1.5", 1, nil},
+		{"1e-20", "", 0, ErrPrecOutOfRange},
+		{"1.23456e-15", "", 0, ErrPrecOutOfRange},
+		{"1e200", "", 0, ErrExponentOutOfRange},
+		{"1e999999999999999999999", "", 0, ErrExponentOutOfRange},
+		{"1e", "", 0, ErrInvalidFormat},
+		{"1e+", "", 0, ErrInvalidFormat},
+		{"1.e5", "", 0, ErrInvalidFormat},
+		{".5e5", "", 0, ErrInvalidFormat},
+		{"e5", "", 0, ErrInvalidFormat},
+		{"-e5", "", 0, ErrInvalidFormat},
+		{"1e5.5", "", 0, ErrInvalidFormat},
+		{"1ee5", "", 0, ErrInvalidFormat},
+		{"1e 5", "", 0, ErrInvalidFormat},
+		{"1.2.3e5", "", 0, ErrInvalidFormat},
+		{"1x2e5", "", 0, ErrInvalidFormat},
+		{"", "", 0, ErrEmptyString},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			d, err := ctx.Parse(tc.input)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, d.String())
+			require.Equal(t, tc.prec, d.PrecUint())
+		})
+	}
+}
+
+func TestParseExponentTrunc(t *testing.T) {
+	ctx := DefaultContext().WithParseMode(ParseModeTrunc).WithParseExponent(true)
+
+	testcases := []struct {
+		input string
+		want  string
+	}{
+		{"1e-20", "0"},
+		{"-1e-500", "0"},
+		{"1.23456e-15", "0.0000000000000012345"},
+		{"-9.87654321e-16", "-0.0000000000000009876"},
+		{"1.234567890123456789012345e3", "1234.5678901234567890123"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			d, err := ctx.Parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, d.String())
+		})
+	}
+}
@@ -0,0 +1,373 @@
+// Package bond prices fixed-coupon bonds with [udecimal.Decimal]: accrued interest, clean and dirty price
+// from yield, yield to maturity from price, Macaulay and modified duration and convexity.
+//
+// Prices follow the street convention: the cash flows are discounted at the yield compounded once per
+// coupon period, and the fraction of the first period is computed with the day count convention of the bond.
+// The discount factor of the fractional first period is computed with [udecimal.Decimal.Pow], and those of
+// the following periods by multiplying with the discount factor of one period. Like the udecimal package,
+// results are truncated to the default precision and never rounded, so they can be rounded to the cent
+// (or to the price tick) exactly once by the caller.
+//
+// The yield is solved with Newton's method, and [ErrNoConvergence] is returned when the solver doesn't converge.
+package bond
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/markovichecha/udecimal"
+	"github.com/markovichecha/udecimal/daycount"
+)
+
+var (
+	// ErrInvalidBond is returned when the face value is not positive, the coupon rate is negative
+	// or the frequency is not one of 1, 2, 3, 4, 6 or 12
+	ErrInvalidBond = fmt.Errorf("bond: invalid bond")
+
+	// ErrMatured is returned when the settlement date is not before the maturity date
+	ErrMatured = fmt.Errorf("bond: settlement date must be before maturity date")
+
+	// ErrInvalidYield is returned when 1 + yield/frequency is not positive
+	ErrInvalidYield = fmt.Errorf("bond: yield must be greater than -frequency")
+
+	// ErrInvalidPrice is returned when the price is not positive
+	ErrInvalidPrice = fmt.Errorf("bond: price must be positive")
+
+	// ErrNoConvergence is returned when the yield solver doesn't converge
+	ErrNoConvergence = fmt.Errorf("bond: solver did not converge")
+)
+
+var one = udecimal.One
+
+// Bond is a bond that pays a fixed coupon Frequency times per year and repays Face at maturity.
+//
+// Coupon dates are generated backwards from Maturity in steps of 12/Frequency months. When Maturity is the last day
+// of its month, so is every coupon date; otherwise the day is clamped to the end of shorter months.
+// Irregular (short or long) first coupons are not supported.
+type Bond struct {
+	// Face is the amount repaid at maturity, e.g. 100 to get prices per 100 of face value.
+	Face udecimal.Decimal
+
+	// Coupon is the yearly coupon rate, e.g. 0.05 for 5%. Each coupon is Face * Coupon / Frequency.
+	Coupon udecimal.Decimal
+
+	// Maturity is the date Face and the last coupon are paid. Only the date is used.
+	Maturity time.Time
+
+	// Frequency is the number of coupons per year: 1, 2, 3, 4, 6 or 12.
+	Frequency int
+
+	// DayCount is the convention used for the accrued interest and the fraction of the current coupon period.
+	// If nil, ACT/ACT ICMA over the current coupon period is used.
+	DayCount daycount.Convention
+}
+
+// Analytics are the interest rate sensitivities of a bond at a given yield.
+type Analytics struct {
+	// DirtyPrice is the present value of the remaining cash flows.
+	DirtyPrice udecimal.Decimal
+
+	// Macaulay is the Macaulay duration in years: the average time of the cash flows weighted by their present value.
+	Macaulay udecimal.Decimal
+
+	// Modified is the modified duration, Macaulay / (1 + yield/Frequency):
+	// the relative change of the dirty price for a change of the yield, -dP/dy / P.
+	Modified udecimal.Decimal
+
+	// Convexity is d²P/dy² / P, in years squared.
+	Convexity udecimal.Decimal
+}
+
+// AccruedInterest returns the coupon interest accrued from the previous coupon date to settle:
+//
+//	AccruedInterest = Face * Coupon * (year fraction from the previous coupon to settle)
+//
+// With ACT/ACT ICMA (the default), this is the coupon times the fraction of the coupon period that has passed.
+//
+// Returns error if:
+//   - the bond is not valid ([ErrInvalidBond])
+//   - settle is not before the maturity ([ErrMatured])
+//
+// Example:
+//
+//	Bond{Face: 100, Coupon: 0.0575, Maturity: 2017-11-15, Frequency: 2, DayCount: daycount.Thirty360US}.
+//	  AccruedInterest(2008-02-15) = 1.4375
+func (b Bond) AccruedInterest(settle time.Time) (udecimal.Decimal, error) {
+	p, err := b.period(settle)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return p.accrued, nil
+}
+
+// DirtyPrice returns the price including the accrued interest, i.e. the present value of the remaining cash flows
+// discounted at yield compounded Frequency times per year:
+//
+//	DirtyPrice = sum(CF[k] / (1 + yield/Frequency)^(k - 1 + w)), k = 1..N
+//
+// where CF[k] are the remaining coupons (and Face at maturity) and w is the fraction of the current coupon period
+// left until the next coupon.
+//
+// Returns error if:
+//   - the bond is not valid ([ErrInvalidBond])
+//   - settle is not before the maturity ([ErrMatured])
+//   - 1 + yield/Frequency is not positive ([ErrInvalidYield])
+func (b Bond) DirtyPrice(settle time.Time, yield udecimal.Decimal) (udecimal.Decimal, error) {
+	p, err := b.period(settle)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	pv, err := b.presentValue(p, yield)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return pv.price, nil
+}
+
+// CleanPrice returns the dirty price minus the accrued interest, which is how bond prices are quoted.
+//
+// Returns error if:
+//   - the bond is not valid ([ErrInvalidBond])
+//   - settle is not before the maturity ([ErrMatured])
+//   - 1 + yield/Frequency is not positive ([ErrInvalidYield])
+//
+// Example:
+//
+//	Bond{Face: 100, Coupon: 0.0575, Maturity: 2017-11-15, Frequency: 2, DayCount: daycount.Thirty360US}.
+//	  CleanPrice(2008-02-15, 0.065) = 94.6343616213...
+func (b Bond) CleanPrice(settle time.Time, yield udecimal.Decimal) (udecimal.Decimal, error) {
+	p, err := b.period(settle)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	pv, err := b.presentValue(p, yield)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return pv.price.Sub(p.accrued), nil
+}
+
+// Yield returns the yield to maturity at which the clean price of the bond is cleanPrice.
+//
+// The yield is solved with Newton's method starting from the coupon rate.
+//
+// Returns error if:
+//   - the bond is not valid ([ErrInvalidBond])
+//   - settle is not before the maturity ([ErrMatured])
+//   - cleanPrice + accrued interest is not positive ([ErrInvalidPrice])
+//   - the solver doesn't converge ([ErrNoConvergence])
+//
+// Example:
+//
+//	Bond{Face: 100, Coupon: 0.0575, Maturity: 2016-11-15, Frequency: 2, DayCount: daycount.Thirty360US}.
+//	  Yield(2008-02-15, 95.04287) = 0.0650000068...
+func (b Bond) Yield(settle time.Time, cleanPrice udecimal.Decimal) (udecimal.Decimal, error) {
+	p, err := b.period(settle)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	dirty := cleanPrice.Add(p.accrued)
+	if !dirty.IsPos() {
+		return udecimal.Decimal{}, ErrInvalidPrice
+	}
+
+	guess := b.Coupon
+	if guess.IsZero() {
+		guess = defaultGuess
+	}
+
+	f := udecimal.MustFromInt64(int64(b.Frequency), 0)
+
+	return solve(guess, func(y udecimal.Decimal) (udecimal.Decimal, udecimal.Decimal, error) {
+		pv, err := b.presentValue(p, y)
+		if err != nil {
+			return udecimal.Decimal{}, udecimal.Decimal{}, err
+		}
+
+		// dP/dy = -sum(t * CF * v^(t+1)) / f
+		deriv, err := pv.timeWeighted.Mul(pv.discount).Div(f)
+		if err != nil {
+			return udecimal.Decimal{}, udecimal.Decimal{}, err
+		}
+
+		return pv.price.Sub(dirty), deriv.Neg(), nil
+	})
+}
+
+// Analytics returns the dirty price, durations and convexity of the bond at yield.
+//
+// Returns error if:
+//   - the bond is not valid ([ErrInvalidBond])
+//   - settle is not before the maturity ([ErrMatured])
+//   - 1 + yield/Frequency is not positive ([ErrInvalidYield])
+//
+// Example:
+//
+//	Bond{Face: 100, Coupon: 0.08, Maturity: 2016-01-01, Frequency: 2}.Analytics(2008-01-01, 0.09) =
+//	  Macaulay 5.993774..., Modified 5.735669...
+func (b Bond) Analytics(settle time.Time, yield udecimal.Decimal) (Analytics, error) {
+	p, err := b.period(settle)
+	if err != nil {
+		return Analytics{}, err
+	}
+
+	pv, err := b.presentValue(p, yield)
+	if err != nil {
+		return Analytics{}, err
+	}
+
+	f := udecimal.MustFromInt64(int64(b.Frequency), 0)
+	fp := f.Mul(pv.price)
+
+	// Macaulay = sum(t * CF * v^t) / (f * P)
+	macaulay, err := pv.timeWeighted.Div(fp)
+	if err != nil {
+		return Analytics{}, err
+	}
+
+	// convexity = sum(t * (t+1) * CF * v^(t+2)) / (f^2 * P)
+	convexity, err := pv.convexityWeighted.Mul(pv.discount).Mul(pv.discount).Div(fp.Mul(f))
+	if err != nil {
+		return Analytics{}, err
+	}
+
+	return Analytics{
+		DirtyPrice: pv.price,
+		Macaulay:   macaulay,
+		Modified:   macaulay.Mul(pv.discount),
+		Convexity:  convexity,
+	}, nil
+}
+
+// couponPeriodInfo is the state of the bond at a settlement date
+type couponPeriodInfo struct {
+	// number of remaining coupons
+	n int
+
+	// fraction of the current coupon period left until the next coupon, 0 < w <= 1
+	w udecimal.Decimal
+
+	accrued udecimal.Decimal
+}
+
+// period validates the bond and settle and returns the current coupon period
+func (b Bond) period(settle time.Time) (couponPeriodInfo, error) {
+	if !b.Face.IsPos() || b.Coupon.IsNeg() || b.Frequency <= 0 || 12%b.Frequency != 0 {
+		return couponPeriodInfo{}, ErrInvalidBond
+	}
+
+	if !civilDate(settle).Before(civilDate(b.Maturity)) {
+		return couponPeriodInfo{}, ErrMatured
+	}
+
+	prev, next, n := b.couponPeriod(settle)
+
+	conv := b.DayCount
+	if conv == nil {
+		conv = daycount.ActActICMA{RefStart: prev, RefEnd: next, Frequency: b.Frequency}
+	}
+
+	num, den, err := conv.Fraction(prev, settle)
+	if err != nil {
+		return couponPeriodInfo{}, err
+	}
+
+	// accrued = Face * Coupon * num / den
+	accrued, err := b.Face.Mul(b.Coupon).Mul(udecimal.MustFromInt64(num, 0)).Div(udecimal.MustFromInt64(den, 0))
+	if err != nil {
+		return couponPeriodInfo{}, err
+	}
+
+	// w = 1 - Frequency * num / den, the elapsed fraction of the period is capped at 1 for conventions
+	// that count more than 1/Frequency of a year in a coupon period (e.g. ACT/360)
+	elapsed := int64(b.Frequency) * num
+	w, err := udecimal.MustFromInt64(max(den-elapsed, 0), 0).Div(udecimal.MustFromInt64(den, 0))
+	if err != nil {
+		return couponPeriodInfo{}, err
+	}
+
+	return couponPeriodInfo{n: n, w: w, accrued: accrued}, nil
+}
+
+// presentValue is the result of discounting the cash flows of a bond
+type presentValue struct {
+	// discount factor per period, v = 1 / (1 + yield/f)
+	discount udecimal.Decimal
+
+	// sum(CF * v^t)
+	price udecimal.Decimal
+
+	// sum(t * CF * v^t), t in periods
+	timeWeighted udecimal.Decimal
+
+	// sum(t * (t+1) * CF * v^t)
+	convexityWeighted udecimal.Decimal
+}
+
+// presentValue discounts the remaining cash flows of b at yield.
+// The k-th cash flow (k = 1..n) is paid after t = k - 1 + w periods.
+func (b Bond) presentValue(p couponPeriodInfo, yield udecimal.Decimal) (presentValue, error) {
+	f := udecimal.MustFromInt64(int64(b.Frequency), 0)
+
+	rate, err := yield.Div(f)
+	if err != nil {
+		return presentValue{}, err
+	}
+
+	base := one.Add(rate)
+	if !base.IsPos() {
+		return presentValue{}, ErrInvalidYield
+	}
+
+	v, err := one.Div(base)
+	if err != nil {
+		return presentValue{}, err
+	}
+
+	// v^w = 1 / base^w
+	vt := v
+	if !p.w.Equal(one) {
+		bw, err := base.Pow(p.w)
+		if err != nil {
+			return presentValue{}, err
+		}
+
+		if vt, err = one.Div(bw); err != nil {
+			return presentValue{}, err
+		}
+	}
+
+	coupon, err := b.Face.Mul(b.Coupon).Div(f)
+	if err != nil {
+		return presentValue{}, err
+	}
+
+	pv := presentValue{discount: v, price: udecimal.Zero, timeWeighted: udecimal.Zero, convexityWeighted: udecimal.Zero}
+	t := p.w
+	for k := 1; k <= p.n; k++ {
+		cf := coupon
+		if k == p.n {
+			cf = cf.Add(b.Face)
+		}
+
+		term := cf.Mul(vt)
+		tTerm := t.Mul(term)
+
+		pv.price = pv.price.Add(term)
+		pv.timeWeighted = pv.timeWeighted.Add(tTerm)
+		pv.convexityWeighted = pv.convexityWeighted.Add(t.Add(one).Mul(tTerm))
+
+		vt = vt.Mul(v)
+		t = t.Add(one)
+	}
+
+	return pv, nil
+}
+
+var defaultGuess = udecimal.MustParse("0.05")
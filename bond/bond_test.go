@@ -0,0 +1,187 @@
+package bond
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markovichecha/udecimal"
+	"github.com/markovichecha/udecimal/daycount"
+)
+
+// results are compared after rounding to 10 digits, the expected values are computed with 60 significant digits
+const cmpPrec = 10
+
+func requireDecimal(t *testing.T, want string, got udecimal.Decimal) {
+	t.Helper()
+	require.Equal(t, want, got.Round(cmpPrec, udecimal.RoundHalfEven).String())
+}
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+func TestPrice(t *testing.T) {
+	m := udecimal.MustParse
+
+	testcases := []struct {
+		name     string
+		bond     Bond
+		settle   string
+		yield    string
+		accrued  string
+		clean    string
+		dirty    string
+		macaulay string
+		modified string
+		convex   string
+	}{
+		{
+			"30/360 mid period",
+			Bond{Face: m("100"), Coupon: m("0.0575"), Maturity: date("2017-11-15"), Frequency: 2, DayCount: daycount.Thirty360US},
+			"2008-02-15", "0.065",
+			"1.4375", "94.6343616213", "96.0718616213", "7.4164846964", "7.1830360255", "64.8977445731",
+		},
+		{
+			"ICMA on coupon date",
+			Bond{Face: m("100"), Coupon: m("0.08"), Maturity: date("2016-01-01"), Frequency: 2},
+			"2008-01-01", "0.09",
+			"0", "94.3829924754", "94.3829924754", "5.9937749555", "5.7356698139", "41.9576028358",
+		},
+		{
+			"ICMA mid period",
+			Bond{Face: m("100"), Coupon: m("0.08"), Maturity: date("2016-01-01"), Frequency: 2},
+			"2008-03-15", "0.09",
+			"1.6263736264", "94.4609950393", "96.0873686657", "5.7904782522", "5.5411275141", "39.6707063091",
+		},
+		{
+			"last period",
+			Bond{Face: m("1000"), Coupon: m("0.04"), Maturity: date("2025-06-15"), Frequency: 4},
+			"2025-05-01", "0.05",
+			"5.1086956522", "998.7729166118", "1003.881612264", "0.1222826087", "0.1207729469", "0.0444065854",
+		},
+		{
+			"zero coupon",
+			Bond{Face: m("100"), Coupon: m("0"), Maturity: date("2030-07-01"), Frequency: 1, DayCount: daycount.Act365Fixed},
+			"2025-01-01", "0.03",
+			"0", "85.0056944852", "85.0056944852", "5.495890411", "5.335815933", "33.651335489",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			settle, yield := date(tc.settle), m(tc.yield)
+
+			accrued, err := tc.bond.AccruedInterest(settle)
+			require.NoError(t, err)
+			requireDecimal(t, tc.accrued, accrued)
+
+			clean, err := tc.bond.CleanPrice(settle, yield)
+			require.NoError(t, err)
+			requireDecimal(t, tc.clean, clean)
+
+			dirty, err := tc.bond.DirtyPrice(settle, yield)
+			require.NoError(t, err)
+			requireDecimal(t, tc.dirty, dirty)
+
+			a, err := tc.bond.Analytics(settle, yield)
+			require.NoError(t, err)
+			requireDecimal(t, tc.dirty, a.DirtyPrice)
+			requireDecimal(t, tc.macaulay, a.Macaulay)
+			requireDecimal(t, tc.modified, a.Modified)
+			requireDecimal(t, tc.convex, a.Convexity)
+
+			// the yield of the clean price is the original yield
+			y, err := tc.bond.Yield(settle, clean)
+			require.NoError(t, err)
+			requireDecimal(t, tc.yield, y)
+		})
+	}
+}
+
+func TestYield(t *testing.T) {
+	m := udecimal.MustParse
+	b := Bond{Face: m("100"), Coupon: m("0.0575"), Maturity: date("2016-11-15"), Frequency: 2, DayCount: daycount.Thirty360US}
+
+	y, err := b.Yield(date("2008-02-15"), m("95.04287"))
+	require.NoError(t, err)
+	requireDecimal(t, "0.0650000069", y)
+
+	// negative yield
+	y, err = b.Yield(date("2008-02-15"), m("160"))
+	require.NoError(t, err)
+	require.True(t, y.IsNeg())
+
+	clean, err := b.CleanPrice(date("2008-02-15"), y)
+	require.NoError(t, err)
+	requireDecimal(t, "160", clean)
+}
+
+func TestSchedule(t *testing.T) {
+	m := udecimal.MustParse
+
+	testcases := []struct {
+		name       string
+		maturity   string
+		frequency  int
+		settle     string
+		prev, next string
+		n          int
+	}{
+		{"end of month", "2025-08-31", 2, "2024-03-01", "2024-02-29", "2024-08-31", 3},
+		{"end of month on coupon", "2025-08-31", 2, "2025-02-28", "2025-02-28", "2025-08-31", 1},
+		{"clamped", "2025-05-30", 4, "2025-01-15", "2024-11-30", "2025-02-28", 2},
+		{"on coupon date", "2025-05-30", 4, "2024-11-30", "2024-11-30", "2025-02-28", 2},
+		{"day before maturity", "2030-01-15", 1, "2030-01-14", "2029-01-15", "2030-01-15", 1},
+		{"monthly", "2026-01-10", 12, "2024-12-31", "2024-12-10", "2025-01-10", 13},
+		{"long", "2054-06-01", 2, "2024-06-02", "2024-06-01", "2024-12-01", 60},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := Bond{Face: m("100"), Coupon: m("0.05"), Maturity: date(tc.maturity), Frequency: tc.frequency}
+			settle := date(tc.settle)
+
+			require.Equal(t, tc.prev, b.PreviousCoupon(settle).Format(time.DateOnly))
+			require.Equal(t, tc.next, b.NextCoupon(settle).Format(time.DateOnly))
+			require.Equal(t, tc.n, b.CouponsRemaining(settle))
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	m := udecimal.MustParse
+	valid := Bond{Face: m("100"), Coupon: m("0.05"), Maturity: date("2030-01-01"), Frequency: 2}
+	settle := date("2025-01-01")
+
+	for _, b := range []Bond{
+		{Face: m("0"), Coupon: m("0.05"), Maturity: valid.Maturity, Frequency: 2},
+		{Face: m("100"), Coupon: m("-0.05"), Maturity: valid.Maturity, Frequency: 2},
+		{Face: m("100"), Coupon: m("0.05"), Maturity: valid.Maturity, Frequency: 0},
+		{Face: m("100"), Coupon: m("0.05"), Maturity: valid.Maturity, Frequency: 5},
+	} {
+		_, err := b.CleanPrice(settle, m("0.05"))
+		require.ErrorIs(t, err, ErrInvalidBond)
+	}
+
+	_, err := valid.AccruedInterest(valid.Maturity)
+	require.ErrorIs(t, err, ErrMatured)
+
+	_, err = valid.DirtyPrice(settle, m("-2"))
+	require.ErrorIs(t, err, ErrInvalidYield)
+
+	_, err = valid.Analytics(settle, m("-3"))
+	require.ErrorIs(t, err, ErrInvalidYield)
+
+	_, err = valid.Yield(settle, m("-1"))
+	require.ErrorIs(t, err, ErrInvalidPrice)
+
+	_, err = valid.Yield(settle, m("1000000000000"))
+	require.ErrorIs(t, err, ErrNoConvergence)
+}
@@ -0,0 +1,34 @@
+package bond
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/markovichecha/udecimal"
+	"github.com/markovichecha/udecimal/daycount"
+)
+
+func ExampleBond() {
+	b := Bond{
+		Face:      udecimal.MustParse("100"),
+		Coupon:    udecimal.MustParse("0.0575"),
+		Maturity:  time.Date(2017, time.November, 15, 0, 0, 0, 0, time.UTC),
+		Frequency: 2,
+		DayCount:  daycount.Thirty360US,
+	}
+	settle := time.Date(2008, time.February, 15, 0, 0, 0, 0, time.UTC)
+
+	accrued, _ := b.AccruedInterest(settle)
+	clean, _ := b.CleanPrice(settle, udecimal.MustParse("0.065"))
+	fmt.Println(accrued, clean.Round(6, udecimal.RoundHalfEven))
+
+	y, _ := b.Yield(settle, udecimal.MustParse("94.634362"))
+	fmt.Println(y.Round(8, udecimal.RoundHalfEven))
+
+	a, _ := b.Analytics(settle, y)
+	fmt.Println(a.Macaulay.Round(4, udecimal.RoundHalfEven), a.Modified.Round(4, udecimal.RoundHalfEven), a.Convexity.Round(2, udecimal.RoundHalfEven))
+	// Output:
+	// 1.4375 94.634362
+	// 0.065
+	// 7.4165 7.183 64.9
+}
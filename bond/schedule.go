@@ -0,0 +1,78 @@
+package bond
+
+import (
+	"time"
+)
+
+// PreviousCoupon returns the last coupon date on or before settle.
+// Coupon dates are generated backwards from the maturity, see [Bond].
+func (b Bond) PreviousCoupon(settle time.Time) time.Time {
+	prev, _, _ := b.couponPeriod(settle)
+	return prev
+}
+
+// NextCoupon returns the first coupon date after settle.
+// Coupon dates are generated backwards from the maturity, see [Bond].
+func (b Bond) NextCoupon(settle time.Time) time.Time {
+	_, next, _ := b.couponPeriod(settle)
+	return next
+}
+
+// CouponsRemaining returns the number of coupons paid after settle, including the one paid at maturity.
+func (b Bond) CouponsRemaining(settle time.Time) int {
+	_, _, n := b.couponPeriod(settle)
+	return n
+}
+
+// couponPeriod returns the coupon period that contains settle, prev <= settle < next,
+// and the number of coupons paid after settle. b must be valid and settle before maturity.
+func (b Bond) couponPeriod(settle time.Time) (prev, next time.Time, n int) {
+	months := 12 / b.Frequency
+	s := civilDate(settle)
+
+	// estimate the number of periods from the months between settle and maturity, then adjust
+	y1, m1, _ := s.Date()
+	y2, m2, _ := b.Maturity.Date()
+	n = max(1, ((y2-y1)*12+int(m2-m1))/months)
+
+	for n > 1 && !b.couponDate(n-1).After(s) {
+		n--
+	}
+
+	for b.couponDate(n).After(s) {
+		n++
+	}
+
+	return b.couponDate(n), b.couponDate(n - 1), n
+}
+
+// couponDate returns the coupon date k periods before the maturity
+func (b Bond) couponDate(k int) time.Time {
+	return addMonths(civilDate(b.Maturity), -k*12/b.Frequency)
+}
+
+// addMonths adds months to t. The day is clamped to the end of the month,
+// and stays at the end of the month if t is the last day of its month.
+func addMonths(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	eom := d == daysIn(y, m)
+
+	first := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	last := daysIn(first.Year(), first.Month())
+
+	if eom || d > last {
+		d = last
+	}
+
+	return time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, time.UTC)
+}
+
+func daysIn(y int, m time.Month) int {
+	return time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// civilDate returns the date of t at midnight UTC, ignoring the time of day and the time zone
+func civilDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,18 @@
+package bond
+
+import (
+	"github.com/markovichecha/udecimal"
+	"github.com/markovichecha/udecimal/internal/solver"
+)
+
+// solve finds a root of f with Newton's method starting from guess, see [solver.Newton].
+// f returns the value and the derivative of the function at y, an error when the yield
+// left the domain of f (e.g. yield <= -frequency) or the values overflow.
+func solve(guess udecimal.Decimal, f solver.Func) (udecimal.Decimal, error) {
+	y, ok := solver.Newton(guess, f)
+	if !ok {
+		return udecimal.Decimal{}, ErrNoConvergence
+	}
+
+	return y, nil
+}
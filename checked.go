@@ -0,0 +1,103 @@
+package udecimal
+
+import "fmt"
+
+// ErrOverflow is returned by the checked arithmetic operations (AddChecked, SubChecked, MulChecked, DivChecked)
+// when an operand or the result doesn't fit into the 128-bit coefficient.
+var ErrOverflow = fmt.Errorf("overflow: coefficient doesn't fit into 128 bits")
+
+// AddChecked returns d + e.
+// Unlike Add, it never falls back to big.Int, so it doesn't allocate memory.
+//
+// Returns [ErrOverflow] if d, e or the result coefficient doesn't fit into 128 bits.
+func (d Decimal) AddChecked(e Decimal) (Decimal, error) {
+	return d.addChecked(e.neg, e)
+}
+
+// SubChecked returns d - e.
+// Unlike Sub, it never falls back to big.Int, so it doesn't allocate memory.
+//
+// Returns [ErrOverflow] if d, e or the result coefficient doesn't fit into 128 bits.
+func (d Decimal) SubChecked(e Decimal) (Decimal, error) {
+	return d.addChecked(!e.neg, e)
+}
+
+// addChecked returns d + e, where the sign of e is replaced by eNeg
+func (d Decimal) addChecked(eNeg bool, e Decimal) (Decimal, error) {
+	if d.coef.overflow() || e.coef.overflow() {
+		return Decimal{}, ErrOverflow
+	}
+
+	dcoef, ecoef := d.coef.u128, e.coef.u128
+	prec := max(d.prec, e.prec)
+
+	var err error
+
+	switch {
+	case d.prec > e.prec:
+		ecoef, err = ecoef.Mul(pow10[d.prec-e.prec])
+	case d.prec < e.prec:
+		dcoef, err = dcoef.Mul(pow10[e.prec-d.prec])
+	}
+
+	if err != nil {
+		return Decimal{}, ErrOverflow
+	}
+
+	if d.neg == eNeg {
+		coef, err := dcoef.Add(ecoef)
+		if err != nil {
+			return Decimal{}, ErrOverflow
+		}
+
+		return newDecimal(d.neg, bintFromU128(coef), prec), nil
+	}
+
+	// different sign
+	if dcoef.Cmp(ecoef) > 0 {
+		// dcoef > ecoef, subtract can't overflow
+		coef, _ := dcoef.Sub(ecoef)
+		return newDecimal(d.neg, bintFromU128(coef), prec), nil
+	}
+
+	// dcoef <= ecoef
+	coef, _ := ecoef.Sub(dcoef)
+	return newDecimal(eNeg, bintFromU128(coef), prec), nil
+}
+
+// MulChecked returns d * e.
+// The result will have at most defaultPrec digits after the decimal point.
+// Unlike Mul, it never falls back to big.Int, so it doesn't allocate memory.
+//
+// Returns [ErrOverflow] if d, e or the result coefficient doesn't fit into 128 bits.
+func (d Decimal) MulChecked(e Decimal) (Decimal, error) {
+	q, err := tryMulU128(d, e, d.neg != e.neg, d.prec+e.prec)
+	if err != nil {
+		return Decimal{}, ErrOverflow
+	}
+
+	return q, nil
+}
+
+// DivChecked returns d / e.
+// If the result has more than defaultPrec fraction digits, it will be truncated to defaultPrec digits.
+// Unlike Div, it never falls back to big.Int, so it doesn't allocate memory.
+//
+// Returns error if:
+//   - e is zero ([ErrDivideByZero])
+//   - d, e or the result coefficient doesn't fit into 128 bits ([ErrOverflow])
+func (d Decimal) DivChecked(e Decimal) (Decimal, error) {
+	if e.coef.IsZero() {
+		return Decimal{}, ErrDivideByZero
+	}
+
+	// see Div
+	d = d.Trunc(defaultPrec + e.prec)
+
+	q, err := tryDivU128(d, e, d.neg != e.neg)
+	if err != nil {
+		return Decimal{}, ErrOverflow
+	}
+
+	return q, nil
+}
@@ -0,0 +1,152 @@
+package udecimal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const maxU128Str = "340282366920938463463374607431768211455"
+
+func TestAddSubChecked(t *testing.T) {
+	testcases := []struct {
+		a, b    string
+		wantAdd string
+		wantSub string
+		wantErr error
+	}{
+		{"1", "2", "3", "-1", nil},
+		{"-1", "2", "1", "-3", nil},
+		{"1.123", "-2.45", "-1.327", "3.573", nil},
+		{"0", "0", "0", "0", nil},
+		{"1.5", "1.5", "3", "0", nil},
+		{"0.0000000000000000001", "12345678901234567890", "12345678901234567890.0000000000000000001", "-12345678901234567889.9999999999999999999", nil},
+		{maxU128Str, "0", maxU128Str, maxU128Str, nil},
+		{maxU128Str, "-" + maxU128Str, "0", "", ErrOverflow},
+		{maxU128Str, "1", "", "340282366920938463463374607431768211454", ErrOverflow},
+		{"34028236692093846346337460743176821145.5", "0.1", "", "34028236692093846346337460743176821145.4", ErrOverflow},
+		{"34028236692093846346337460743176821145", "0.1", "34028236692093846346337460743176821145.1", "34028236692093846346337460743176821144.9", nil},
+		{"34028236692093846346337460743176821146", "0.1", "", "", ErrOverflow},
+		{"340282366920938463463374607431768211456", "1", "", "", ErrOverflow},
+		{"1", "-340282366920938463463374607431768211456", "", "", ErrOverflow},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%s", tc.a, tc.b), func(t *testing.T) {
+			a := MustParse(tc.a)
+			b := MustParse(tc.b)
+
+			c, err := a.AddChecked(b)
+			if tc.wantAdd == "" {
+				require.Equal(t, tc.wantErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantAdd, c.String())
+				require.Equal(t, a.Add(b), c)
+			}
+
+			c, err = a.SubChecked(b)
+			if tc.wantSub == "" {
+				require.Equal(t, tc.wantErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantSub, c.String())
+				require.Equal(t, a.Sub(b), c)
+			}
+		})
+	}
+}
+
+func TestMulChecked(t *testing.T) {
+	testcases := []struct {
+		a, b    string
+		want    string
+		wantErr error
+	}{
+		{"1.5", "2", "3", nil},
+		{"-1.5", "2.25", "-3.375", nil},
+		{"0", "123.456", "0", nil},
+		{"0.0000000000000000001", "0.1", "0", nil},
+		{"1234567890.123456789", "1234567890.123456789", "1524157875323883675.019051998750190521", nil},
+		{"18446744073709551616", "18446744073709551615", "340282366920938463444927863358058659840", nil},
+		{"1844674407370955161.6", "1844674407370955161.5", "3402823669209384634449278633580586598.4", nil},
+		{"18446744073709551616", "18446744073709551616", "", ErrOverflow},
+		{maxU128Str, "1.1", "", ErrOverflow},
+		{"340282366920938463463374607431768211456", "0", "", ErrOverflow},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s*%s", tc.a, tc.b), func(t *testing.T) {
+			a := MustParse(tc.a)
+			b := MustParse(tc.b)
+
+			c, err := a.MulChecked(b)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, c.String())
+			require.Equal(t, a.Mul(b), c)
+		})
+	}
+}
+
+func TestDivChecked(t *testing.T) {
+	testcases := []struct {
+		a, b    string
+		want    string
+		wantErr error
+	}{
+		{"1", "0", "", ErrDivideByZero},
+		{"1", "3", "0.3333333333333333333", nil},
+		{"-2", "0.5", "-4", nil},
+		{"0", "0.5", "0", nil},
+		{"1", "0.0000000000000000001", "10000000000000000000", nil},
+		{"34028236692093846346", "1", "34028236692093846346", nil},
+		{"34028236692093846347", "1", "", ErrOverflow},
+		{"12345678901234567890", "0.0000000000000000001", "", ErrOverflow},
+		{"340282366920938463463374607431768211456", "2", "", ErrOverflow},
+		{"2", "340282366920938463463374607431768211456", "", ErrOverflow},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s/%s", tc.a, tc.b), func(t *testing.T) {
+			a := MustParse(tc.a)
+			b := MustParse(tc.b)
+
+			c, err := a.DivChecked(b)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, c.String())
+
+			q, err := a.Div(b)
+			require.NoError(t, err)
+			require.Equal(t, q, c)
+		})
+	}
+}
+
+func TestCheckedNoAlloc(t *testing.T) {
+	a := MustParse(maxU128Str)
+	b := MustParse("1234567890.123456789")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = a.AddChecked(b)
+		_, _ = a.SubChecked(b)
+		_, _ = a.MulChecked(b)
+		_, _ = a.DivChecked(b)
+		_, _ = b.AddChecked(b)
+		_, _ = b.SubChecked(b)
+		_, _ = b.MulChecked(b)
+		_, _ = b.DivChecked(b)
+	})
+
+	require.Zero(t, allocs)
+}
@@ -0,0 +1,246 @@
+package udecimal
+
+import "fmt"
+
+// OverflowPolicy specifies what happens when a result coefficient doesn't fit into 128 bits.
+type OverflowPolicy uint8
+
+const (
+	// OverflowBigInt falls back to big.Int, which is the behavior of the package-level API.
+	OverflowBigInt OverflowPolicy = iota
+
+	// OverflowError returns [ErrOverflow] instead of falling back to big.Int.
+	OverflowError
+)
+
+// Context carries the settings used by decimal operations: precision, rounding mode,
+// parse mode, scientific notation parsing and overflow policy.
+//
+// A Context is an immutable value, the With* methods return a modified copy.
+// Unlike [SetDefaultPrecision] and [SetDefaultParseMode], it doesn't change any package state,
+// so different parts of a program can safely use different contexts concurrently.
+//
+// The zero value has precision 0, rounds with [RoundHalfEven], parses with [ParseModeError] without
+// scientific notation and falls back to big.Int on overflow. Use [NewContext] or [DefaultContext] to create one.
+type Context struct {
+	prec      uint8
+	rounding  RoundingMode
+	parseMode ParseMode
+	exponent  bool
+	overflow  OverflowPolicy
+}
+
+// NewContext returns a context with the given precision and rounding mode.
+// The parse mode is [ParseModeError] without scientific notation and the overflow policy is [OverflowBigInt].
+//
+// Panics if prec is greater than 19 (maxPrec) or mode is not a valid [RoundingMode].
+func NewContext(prec uint8, mode RoundingMode) Context {
+	return Context{}.WithPrecision(prec).WithRounding(mode)
+}
+
+// DefaultContext returns the context used by the package-level API:
+// the default precision, the default parse mode without scientific notation, truncation ([RoundDown]) and [OverflowBigInt].
+//
+// Operations of the returned context give the same results as the package-level ones,
+// e.g. DefaultContext().Div(a, b) equals a.Div(b).
+func DefaultContext() Context {
+	return Context{
+		prec:      defaultPrec,
+		rounding:  RoundDown,
+		parseMode: defaultParseMode,
+		overflow:  OverflowBigInt,
+	}
+}
+
+// WithPrecision returns a copy of the context with the given precision.
+//
+// Panics if prec is greater than 19 (maxPrec)
+func (c Context) WithPrecision(prec uint8) Context {
+	if prec > maxPrec {
+		panic(fmt.Sprintf("precision out of range. Only allow maximum %d digits after the decimal points", maxPrec))
+	}
+
+	c.prec = prec
+	return c
+}
+
+// WithRounding returns a copy of the context with the given rounding mode.
+//
+// Panics if mode is not a valid [RoundingMode]
+func (c Context) WithRounding(mode RoundingMode) Context {
+	if !mode.valid() {
+		panic(fmt.Sprintf("can't set rounding mode: invalid rounding mode %d", mode))
+	}
+
+	c.rounding = mode
+	return c
+}
+
+// WithParseMode returns a copy of the context with the given parse mode.
+//
+// Panics if mode is not a valid [ParseMode]
+func (c Context) WithParseMode(mode ParseMode) Context {
+	switch mode {
+	case ParseModeError, ParseModeTrunc:
+		c.parseMode = mode
+		return c
+	default:
+		panic("can't set parse mode: invalid mode value")
+	}
+}
+
+// WithParseExponent returns a copy of the context that accepts (or rejects) numbers in scientific notation,
+// such as 1.23e4 or 5E-3, in Parse.
+func (c Context) WithParseExponent(allow bool) Context {
+	c.exponent = allow
+	return c
+}
+
+// WithOverflowPolicy returns a copy of the context with the given overflow policy.
+//
+// Panics if policy is not a valid [OverflowPolicy]
+func (c Context) WithOverflowPolicy(policy OverflowPolicy) Context {
+	switch policy {
+	case OverflowBigInt, OverflowError:
+		c.overflow = policy
+		return c
+	default:
+		panic("can't set overflow policy: invalid policy value")
+	}
+}
+
+// Precision returns the maximum number of digits after the decimal point of the results
+func (c Context) Precision() uint8 {
+	return c.prec
+}
+
+// Rounding returns the rounding mode used when a result has more than Precision() digits after the decimal point
+func (c Context) Rounding() RoundingMode {
+	return c.rounding
+}
+
+// ParseMode returns the mode used by Parse when the input has more than Precision() digits after the decimal point
+func (c Context) ParseMode() ParseMode {
+	return c.parseMode
+}
+
+// ParseExponent reports whether Parse accepts numbers in scientific notation
+func (c Context) ParseExponent() bool {
+	return c.exponent
+}
+
+// OverflowPolicy returns the policy used when a result coefficient doesn't fit into 128 bits
+func (c Context) OverflowPolicy() OverflowPolicy {
+	return c.overflow
+}
+
+// Parse parses a number in string to a decimal with at most Precision() digits after the decimal point.
+// Depending on the parse mode, exceeding digits return an error matching [ErrPrecOutOfRange] or are truncated.
+//
+// Returns error if:
+//  1. empty/invalid string
+//  2. the number has more than Precision() digits after the decimal point and the parse mode is [ParseModeError]
+//  3. string length exceeds maxStrLen
+//  4. the coefficient doesn't fit into 128 bits and the overflow policy is [OverflowError]
+//  5. the number is in scientific notation and ParseExponent() is false ([ErrInvalidFormat]),
+//     or the exponent makes it longer than maxStrLen ([ErrExponentOutOfRange])
+func (c Context) Parse(s string) (Decimal, error) {
+	neg, coef, prec, err := parseBintExp(unsafeStringToBytes(s), c.prec, c.parseMode, c.exponent, maxStrLen)
+	if err == ErrPrecOutOfRange {
+		return Decimal{}, precOutOfRangeError{prec: c.prec}
+	}
+
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	return c.check(newDecimal(neg, coef, prec))
+}
+
+// precOutOfRangeError reports the precision of the context that rejected the input,
+// ErrPrecOutOfRange always reports the maximum precision.
+type precOutOfRangeError struct {
+	prec uint8
+}
+
+func (e precOutOfRangeError) Error() string {
+	return fmt.Sprintf("precision out of range. Only support maximum %d digits after the decimal point", e.prec)
+}
+
+// Is reports whether target is [ErrPrecOutOfRange].
+func (e precOutOfRangeError) Is(target error) bool {
+	return target == ErrPrecOutOfRange
+}
+
+// MustParse similars to Parse, but panics instead of returning error.
+func (c Context) MustParse(s string) Decimal {
+	d, err := c.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// Round rounds d to Precision() digits after the decimal point using the context rounding mode
+func (c Context) Round(d Decimal) (Decimal, error) {
+	return c.check(d.Round(c.prec, c.rounding))
+}
+
+// Add returns a + b rounded to Precision() digits after the decimal point.
+//
+// Returns [ErrOverflow] if the result coefficient doesn't fit into 128 bits and the overflow policy is [OverflowError].
+func (c Context) Add(a, b Decimal) (Decimal, error) {
+	return c.Round(a.Add(b))
+}
+
+// Sub returns a - b rounded to Precision() digits after the decimal point.
+//
+// Returns [ErrOverflow] if the result coefficient doesn't fit into 128 bits and the overflow policy is [OverflowError].
+func (c Context) Sub(a, b Decimal) (Decimal, error) {
+	return c.Round(a.Sub(b))
+}
+
+// Mul returns a * b rounded to Precision() digits after the decimal point.
+//
+// Returns [ErrOverflow] if the result coefficient doesn't fit into 128 bits and the overflow policy is [OverflowError].
+func (c Context) Mul(a, b Decimal) (Decimal, error) {
+	return c.check(a.mulRound(b, c.prec, c.rounding))
+}
+
+// Div returns a / b rounded to Precision() digits after the decimal point.
+//
+// Returns error if:
+//   - b is zero ([ErrDivideByZero])
+//   - the result coefficient doesn't fit into 128 bits and the overflow policy is [OverflowError] ([ErrOverflow])
+func (c Context) Div(a, b Decimal) (Decimal, error) {
+	q, err := a.divRound(b, c.prec, c.rounding)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	return c.check(q)
+}
+
+// Sqrt returns the square root of d rounded to Precision() digits after the decimal point.
+//
+// Returns error if:
+//   - d is negative ([ErrSqrtNegative])
+//   - the result coefficient doesn't fit into 128 bits and the overflow policy is [OverflowError] ([ErrOverflow])
+func (c Context) Sqrt(d Decimal) (Decimal, error) {
+	q, err := d.sqrtRound(c.prec, c.rounding)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	return c.check(q)
+}
+
+// check applies the overflow policy to d
+func (c Context) check(d Decimal) (Decimal, error) {
+	if c.overflow == OverflowError && d.coef.overflow() && d.coef.bigInt.BitLen() > 128 {
+		return Decimal{}, ErrOverflow
+	}
+
+	return d, nil
+}
@@ -0,0 +1,322 @@
+package udecimal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewContext(t *testing.T) {
+	ctx := NewContext(2, RoundHalfUp)
+	require.Equal(t, uint8(2), ctx.Precision())
+	require.Equal(t, RoundHalfUp, ctx.Rounding())
+	require.Equal(t, ParseModeError, ctx.ParseMode())
+	require.Equal(t, OverflowBigInt, ctx.OverflowPolicy())
+
+	// With* methods return a copy
+	ctx2 := ctx.WithPrecision(5).WithRounding(RoundFloor).WithParseMode(ParseModeTrunc).WithOverflowPolicy(OverflowError)
+	require.Equal(t, uint8(5), ctx2.Precision())
+	require.Equal(t, RoundFloor, ctx2.Rounding())
+	require.Equal(t, ParseModeTrunc, ctx2.ParseMode())
+	require.Equal(t, OverflowError, ctx2.OverflowPolicy())
+
+	require.Equal(t, NewContext(2, RoundHalfUp), ctx)
+
+	require.PanicsWithValue(t, "precision out of range. Only allow maximum 19 digits after the decimal points", func() {
+		_ = NewContext(20, RoundHalfEven)
+	})
+
+	require.PanicsWithValue(t, "can't set rounding mode: invalid rounding mode 9", func() {
+		_ = NewContext(2, RoundingMode(9))
+	})
+
+	require.PanicsWithValue(t, "can't set parse mode: invalid mode value", func() {
+		_ = ctx.WithParseMode(ParseMode(2))
+	})
+
+	require.PanicsWithValue(t, "can't set overflow policy: invalid policy value", func() {
+		_ = ctx.WithOverflowPolicy(OverflowPolicy(2))
+	})
+}
+
+func TestContextParse(t *testing.T) {
+	testcases := []struct {
+		s       string
+		prec    uint8
+		mode    ParseMode
+		want    string
+		wantErr error
+	}{
+		{"1.23", 2, ParseModeError, "1.23", nil},
+		{"1.234", 2, ParseModeError, "", ErrPrecOutOfRange},
+		{"1.239", 2, ParseModeTrunc, "1.23", nil},
+		{"-1.239", 0, ParseModeTrunc, "-1", nil},
+		{"-0.009", 2, ParseModeTrunc, "0", nil},
+		{"123456789012345678901234567890.123456789", 5, ParseModeTrunc, "123456789012345678901234567890.12345", nil},
+		{"123456789012345678901234567890.123456789", 5, ParseModeError, "", ErrPrecOutOfRange},
+		{"1234567890123456789012345678901234567890.123456789", 3, ParseModeTrunc, "1234567890123456789012345678901234567890.123", nil},
+		{"1234567890123456789012345678901234567890.123456789", 3, ParseModeError, "", ErrPrecOutOfRange},
+		{"", 2, ParseModeError, "", ErrEmptyString},
+
+		// the truncated digits are still validated
+		{"1.23abc", 2, ParseModeTrunc, "", ErrInvalidFormat},
+		{"1.23.4", 2, ParseModeTrunc, "", ErrInvalidFormat},
+		{"1.x", 0, ParseModeTrunc, "", ErrInvalidFormat},
+		{"1.5.5", 0, ParseModeTrunc, "", ErrInvalidFormat},
+		{"-1.5-", 0, ParseModeTrunc, "", ErrInvalidFormat},
+		{"12345678901234567890123.23abc", 2, ParseModeTrunc, "", ErrInvalidFormat},
+		{"12345678901234567890123.23.4", 2, ParseModeTrunc, "", ErrInvalidFormat},
+		{"1234567890123456789012345678901234567890.123x", 3, ParseModeTrunc, "", ErrInvalidFormat},
+		{"1234567890123456789012345678901234567890.1.3", 0, ParseModeTrunc, "", ErrInvalidFormat},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%d_%d", tc.s, tc.prec, tc.mode), func(t *testing.T) {
+			ctx := NewContext(tc.prec, RoundHalfEven).WithParseMode(tc.mode)
+
+			d, err := ctx.Parse(tc.s)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, d.String())
+		})
+	}
+
+	// the error reports the context precision, not the maximum one
+	_, err := NewContext(2, RoundHalfEven).Parse("1.005")
+	require.ErrorIs(t, err, ErrPrecOutOfRange)
+	require.EqualError(t, err, "precision out of range. Only support maximum 2 digits after the decimal point")
+
+	_, err = NewContext(0, RoundHalfEven).WithParseExponent(true).Parse("1.5e-1")
+	require.ErrorIs(t, err, ErrPrecOutOfRange)
+	require.EqualError(t, err, "precision out of range. Only support maximum 0 digits after the decimal point")
+}
+
+func TestContextParseExponent(t *testing.T) {
+	ctx := NewContext(3, RoundHalfEven)
+	require.False(t, ctx.ParseExponent())
+
+	_, err := ctx.Parse("15e-3")
+	require.ErrorIs(t, err, ErrInvalidFormat)
+
+	ctx = ctx.WithParseExponent(true)
+	require.True(t, ctx.ParseExponent())
+
+	d, err := ctx.Parse("1.5e-2")
+	require.NoError(t, err)
+	require.Equal(t, "0.015", d.String())
+
+	_, err = ctx.Parse("1.5e-3")
+	require.ErrorIs(t, err, ErrPrecOutOfRange)
+
+	d, err = ctx.WithParseMode(ParseModeTrunc).Parse("1.5e-3")
+	require.NoError(t, err)
+	require.Equal(t, "0.001", d.String())
+
+	// the package default is not changed
+	_, err = Parse("15e-3")
+	require.ErrorIs(t, err, ErrInvalidFormat)
+	require.False(t, DefaultContext().ParseExponent())
+}
+
+func TestContextOps(t *testing.T) {
+	ctx := NewContext(2, RoundHalfEven)
+
+	testcases := []struct {
+		a, b               string
+		add, sub, mul, div string
+	}{
+		{"1.005", "0.001", "1.01", "1", "0", "1005"},
+		{"2", "3", "5", "-1", "6", "0.67"},
+		{"-1.125", "1", "-0.12", "-2.12", "-1.12", "-1.12"},
+		{"0.015", "0.5", "0.52", "-0.48", "0.01", "0.03"},
+		{"12345678901234567890.125", "0.5", "12345678901234567890.62", "12345678901234567889.62", "6172839450617283945.06", "24691357802469135780.25"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%s", tc.a, tc.b), func(t *testing.T) {
+			a := MustParse(tc.a)
+			b := MustParse(tc.b)
+
+			c, err := ctx.Add(a, b)
+			require.NoError(t, err)
+			require.Equal(t, tc.add, c.String())
+
+			c, err = ctx.Sub(a, b)
+			require.NoError(t, err)
+			require.Equal(t, tc.sub, c.String())
+
+			c, err = ctx.Mul(a, b)
+			require.NoError(t, err)
+			require.Equal(t, tc.mul, c.String())
+
+			c, err = ctx.Div(a, b)
+			require.NoError(t, err)
+			require.Equal(t, tc.div, c.String())
+		})
+	}
+
+	_, err := ctx.Div(One, Zero)
+	require.Equal(t, ErrDivideByZero, err)
+
+	s, err := ctx.Sqrt(MustParse("2"))
+	require.NoError(t, err)
+	require.Equal(t, "1.41", s.String())
+
+	s, err = ctx.WithRounding(RoundCeiling).Sqrt(MustParse("2"))
+	require.NoError(t, err)
+	require.Equal(t, "1.42", s.String())
+
+	_, err = ctx.Sqrt(MustParse("-2"))
+	require.Equal(t, ErrSqrtNegative, err)
+}
+
+func TestContextOverflowPolicy(t *testing.T) {
+	maxU128 := MustParse(maxU128Str)
+
+	ctx := NewContext(19, RoundHalfEven)
+	c, err := ctx.Add(maxU128, One)
+	require.NoError(t, err)
+	require.Equal(t, "340282366920938463463374607431768211456", c.String())
+
+	ctx = ctx.WithOverflowPolicy(OverflowError)
+
+	_, err = ctx.Add(maxU128, One)
+	require.Equal(t, ErrOverflow, err)
+
+	_, err = ctx.Mul(maxU128, MustParse("2"))
+	require.Equal(t, ErrOverflow, err)
+
+	_, err = ctx.Div(maxU128, MustParse("0.5"))
+	require.Equal(t, ErrOverflow, err)
+
+	_, err = ctx.Parse("340282366920938463463374607431768211456")
+	require.Equal(t, ErrOverflow, err)
+
+	// the result fits into 128 bits even though an operand doesn't
+	c, err = ctx.Sub(MustParse("340282366920938463463374607431768211456"), One)
+	require.NoError(t, err)
+	require.Equal(t, maxU128Str, c.String())
+
+	c, err = ctx.Mul(maxU128, One)
+	require.NoError(t, err)
+	require.Equal(t, maxU128Str, c.String())
+}
+
+func TestDefaultContext(t *testing.T) {
+	inputs := []string{"0", "1", "-1", "0.5", "3", "-7.25", "1234567890.123456789", "0.0000000000000000001", "12345678901234567890123456789"}
+
+	for _, s := range inputs {
+		for _, e := range inputs {
+			a := MustParse(s)
+			b := MustParse(e)
+
+			ctx := DefaultContext()
+
+			c, err := ctx.Add(a, b)
+			require.NoError(t, err)
+			require.Equal(t, a.Add(b).String(), c.String())
+
+			c, err = ctx.Sub(a, b)
+			require.NoError(t, err)
+			require.Equal(t, a.Sub(b).String(), c.String())
+
+			c, err = ctx.Mul(a, b)
+			require.NoError(t, err)
+			require.Equal(t, a.Mul(b).String(), c.String())
+
+			want, wantErr := a.Div(b)
+			c, err = ctx.Div(a, b)
+			require.Equal(t, wantErr, err)
+			require.Equal(t, want.String(), c.String())
+		}
+
+		d := MustParse(s)
+		want, wantErr := d.Sqrt()
+		got, err := DefaultContext().Sqrt(d)
+		require.Equal(t, wantErr, err)
+		require.Equal(t, want.String(), got.String())
+	}
+}
+
+func TestContextIndependentOfDefaultPrecision(t *testing.T) {
+	SetDefaultPrecision(5)
+	defer SetDefaultPrecision(maxPrec)
+
+	ctx := NewContext(10, RoundHalfEven)
+
+	d, err := ctx.Parse("1.0123456789")
+	require.NoError(t, err)
+	require.Equal(t, "1.0123456789", d.String())
+
+	q, err := ctx.Div(One, MustParse("3"))
+	require.NoError(t, err)
+	require.Equal(t, "0.3333333333", q.String())
+
+	// the package-level API still uses the default precision
+	_, err = Parse("1.0123456789")
+	require.Equal(t, ErrPrecOutOfRange, err)
+
+	q, err = One.Div(MustParse("3"))
+	require.NoError(t, err)
+	require.Equal(t, "0.33333", q.String())
+
+	require.Equal(t, uint8(5), DefaultContext().Precision())
+}
+
+func TestContextValuesWithPackageOps(t *testing.T) {
+	SetDefaultPrecision(10)
+	defer SetDefaultPrecision(maxPrec)
+
+	// decimals parsed by a context can have more digits than the default precision
+	ctx := NewContext(maxPrec, RoundHalfEven)
+
+	testcases := []struct {
+		a, b string
+		want string
+	}{
+		{"1.0000000000000000001", "3", "0.3333333333"},
+		{"-7.9999999999999999999", "0.2", "-39.9999999999"},
+		{"123456789012345678901234.0000000000000000001", "7", "17636684144620811271604.8571428571"},
+		{"1", "0.0000000000000000003", "3333333333333333333.3333333333"},
+		{"0.0000000000000000001", "3", "0"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s/%s", tc.a, tc.b), func(t *testing.T) {
+			a, b := ctx.MustParse(tc.a), ctx.MustParse(tc.b)
+
+			q, err := a.Div(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, q.String())
+
+			if !a.coef.overflow() {
+				q, err = a.DivChecked(b)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, q.String())
+			}
+		})
+	}
+
+	q, err := ctx.MustParse("1.0000000000000000001").Div64(3)
+	require.NoError(t, err)
+	require.Equal(t, "0.3333333333", q.String())
+
+	s, err := ctx.MustParse("2.0000000000000000001").Sqrt()
+	require.NoError(t, err)
+	require.Equal(t, "1.4142135623", s.String())
+
+	SetDefaultPrecision(5)
+
+	s, err = ctx.MustParse("2.0000000000000000001").Sqrt()
+	require.NoError(t, err)
+	require.Equal(t, "1.41421", s.String())
+
+	r, err := ctx.MustParse("8.0000000000000000001").Root(3)
+	require.NoError(t, err)
+	require.Equal(t, "2", r.String())
+}
@@ -0,0 +1,107 @@
+package daycount
+
+import (
+	"math"
+	"time"
+
+	"github.com/markovichecha/udecimal"
+)
+
+// Accrual accrues interest period by period with a day count convention.
+//
+// The interest posted for a period is rounded toward zero to prec digits after the decimal point,
+// and the part below the minor unit is carried forward exactly and added to the next period.
+// This way, the sum of the amounts posted for consecutive periods (e.g. daily) never differs
+// from the amount posted for the whole period (e.g. monthly) by a minor unit or more.
+//
+// An Accrual is not safe for concurrent use.
+type Accrual struct {
+	conv Convention
+	prec uint8
+
+	// exact remainder that is not posted yet, carryNum / carryDen
+	carryNum udecimal.Decimal
+	carryDen int64
+}
+
+// NewAccrual returns an accrual with the convention conv that posts amounts with prec digits after the decimal point.
+func NewAccrual(conv Convention, prec uint8) *Accrual {
+	return &Accrual{conv: conv, prec: prec, carryNum: udecimal.Zero, carryDen: 1}
+}
+
+// Accrue returns the interest principal * rate * (year fraction from start to end) plus the carried remainder,
+// rounded toward zero to the minor unit. The rest is carried to the next call.
+//
+// Returns error if the convention can't compute the year fraction (e.g. [ErrInvalidDates]).
+//
+// Example:
+//
+//	a := NewAccrual(Act360, 2)
+//	a.Accrue(1000, 0.05, 2024-01-01, 2024-01-02) = 0.13 (remainder 0.0088888...)
+//	a.Accrue(1000, 0.05, 2024-01-02, 2024-01-03) = 0.14 (remainder 0.0077777...)
+func (a *Accrual) Accrue(principal, rate udecimal.Decimal, start, end time.Time) (udecimal.Decimal, error) {
+	num, den, err := a.conv.Fraction(start, end)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	// bring the interest and the carry to a common denominator
+	g := gcd(den, a.carryDen)
+	if den/g > math.MaxInt64/a.carryDen {
+		// only with conventions whose denominators keep changing, those of this package are bounded
+		a.foldCarry()
+		g = 1
+	}
+
+	lcm := den / g * a.carryDen
+	total := principal.Mul(rate).Mul(udecimal.MustFromInt64(num, 0)).Mul(udecimal.MustFromInt64(lcm/den, 0)).
+		Add(a.carryNum.Mul(udecimal.MustFromInt64(lcm/a.carryDen, 0)))
+
+	l := udecimal.MustFromInt64(lcm, 0)
+
+	q, err := total.Div(l)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	posted := q.Trunc(a.prec)
+
+	a.carryNum = total.Sub(posted.Mul(l))
+	a.carryDen = lcm
+	if a.carryNum.IsZero() {
+		a.carryDen = 1
+	}
+
+	return posted, nil
+}
+
+// Remainder returns the interest accrued but not posted yet, truncated to the default precision.
+func (a *Accrual) Remainder() udecimal.Decimal {
+	r, err := a.carryNum.Div(udecimal.MustFromInt64(a.carryDen, 0))
+	if err != nil {
+		// unreachable, carryDen is positive
+		panic(err)
+	}
+
+	return r
+}
+
+// Reset discards the remainder.
+func (a *Accrual) Reset() {
+	a.carryNum = udecimal.Zero
+	a.carryDen = 1
+}
+
+// foldCarry replaces the exact carry with its truncated value to keep the denominator small
+func (a *Accrual) foldCarry() {
+	a.carryNum = a.Remainder()
+	a.carryDen = 1
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
@@ -0,0 +1,86 @@
+package daycount
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markovichecha/udecimal"
+)
+
+func TestAccrue(t *testing.T) {
+	m := udecimal.MustParse
+	a := NewAccrual(Act360, 2)
+
+	want := []struct{ posted, remainder string }{
+		{"0.13", "0.0088888888888888888"},
+		{"0.14", "0.0077777777777777777"},
+		{"0.14", "0.0066666666666666666"},
+	}
+
+	day := date("2024-01-01")
+	for _, w := range want {
+		posted, err := a.Accrue(m("1000"), m("0.05"), day, day.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.Equal(t, w.posted, posted.String())
+		require.Equal(t, w.remainder, a.Remainder().String())
+
+		day = day.AddDate(0, 0, 1)
+	}
+
+	a.Reset()
+	require.True(t, a.Remainder().IsZero())
+}
+
+// daily accruals must post the same cumulative amount as monthly accruals at the end of every month.
+// 30/360 US is not tested because its day counts are not additive: the last day of February counts as the 30th
+// only when a period starts on it, so the days of February sum to 28 while the month counts 30.
+func TestAccrueDailyMatchesMonthly(t *testing.T) {
+	m := udecimal.MustParse
+
+	testcases := []struct {
+		principal, rate string
+		conv            Convention
+		prec            uint8
+	}{
+		{"12000", "0.1", Act360, 2},
+		{"360000", "0.1", Act360, 2},
+		{"1234567.89", "0.0425", Act360, 2},
+		{"98765.43", "0.0731", Act365Fixed, 2},
+		{"5000000", "0.0333", ActActISDA, 0},
+		{"777777.77", "0.0123", Thirty360European, 3},
+		{"-4321.09", "0.19", Act360, 2},
+		{"1000000", "0.1375", Bus252{}, 2},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.conv.String()+"/"+tc.principal, func(t *testing.T) {
+			daily, monthly := NewAccrual(tc.conv, tc.prec), NewAccrual(tc.conv, tc.prec)
+			dailyTotal, monthlyTotal := udecimal.Zero, udecimal.Zero
+
+			start := date("2023-12-01")
+			for month := 0; month < 14; month++ {
+				end := start.AddDate(0, 1, 0)
+
+				for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+					posted, err := daily.Accrue(m(tc.principal), m(tc.rate), day, day.AddDate(0, 0, 1))
+					require.NoError(t, err)
+					require.LessOrEqual(t, posted.PrecUint(), tc.prec)
+
+					dailyTotal = dailyTotal.Add(posted)
+				}
+
+				posted, err := monthly.Accrue(m(tc.principal), m(tc.rate), start, end)
+				require.NoError(t, err)
+
+				monthlyTotal = monthlyTotal.Add(posted)
+
+				require.Equal(t, monthlyTotal, dailyTotal, "%s", end.Format(time.DateOnly))
+				require.Equal(t, monthly.Remainder(), daily.Remainder())
+
+				start = end
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package daycount
+
+import (
+	"time"
+)
+
+// Calendar tells which days are business days.
+type Calendar interface {
+	// IsBusinessDay reports whether the date of t is a business day.
+	IsBusinessDay(t time.Time) bool
+}
+
+// Weekends is a calendar where every day except Saturday and Sunday is a business day.
+var Weekends Calendar = weekends{}
+
+type weekends struct{}
+
+func (weekends) IsBusinessDay(t time.Time) bool {
+	wd := t.Weekday()
+	return wd != time.Saturday && wd != time.Sunday
+}
+
+// Holidays is a calendar where every day except Saturday, Sunday and the holidays is a business day.
+type Holidays struct {
+	days map[int64]struct{}
+}
+
+// NewHolidays returns a calendar with the given holidays. Only the dates are used.
+func NewHolidays(holidays ...time.Time) Holidays {
+	days := make(map[int64]struct{}, len(holidays))
+	for _, h := range holidays {
+		days[civilDays(h)] = struct{}{}
+	}
+
+	return Holidays{days: days}
+}
+
+// IsBusinessDay reports whether the date of t is neither a weekend nor a holiday.
+func (h Holidays) IsBusinessDay(t time.Time) bool {
+	if !Weekends.IsBusinessDay(t) {
+		return false
+	}
+
+	_, ok := h.days[civilDays(t)]
+	return !ok
+}
+
+// BusinessDays returns the number of business days of cal from start (inclusive) to end (exclusive),
+// or 0 if end is not after start. If cal is nil, [Weekends] is used.
+func BusinessDays(cal Calendar, start, end time.Time) int64 {
+	if cal == nil {
+		cal = Weekends
+	}
+
+	y, m, d := start.Date()
+	n := Days(start, end)
+
+	var count int64
+	for i := int64(0); i < n; i++ {
+		// time.Date normalizes the day of month
+		if cal.IsBusinessDay(time.Date(y, m, d+int(i), 0, 0, 0, 0, time.UTC)) {
+			count++
+		}
+	}
+
+	return count
+}
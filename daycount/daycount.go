@@ -0,0 +1,271 @@
+// Package daycount computes year fractions between dates with the usual day count conventions
+// (ACT/360, ACT/365F, ACT/ACT ISDA and ICMA, 30/360 US and European, BUS/252) and accrues interest with them.
+//
+// Every year fraction is an exact fraction of two integers, see [Convention]. [YearFraction] turns it
+// into a [udecimal.Decimal] with a single division, truncated to the default precision like the udecimal package,
+// and [Interest] and [Accrual] use the exact fraction so that no precision is lost before rounding.
+//
+// Only the dates of the [time.Time] values are used, the time of day and the time zone are ignored.
+package daycount
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/markovichecha/udecimal"
+)
+
+var (
+	// ErrInvalidDates is returned when the end date is before the start date
+	ErrInvalidDates = fmt.Errorf("daycount: end date must not be before start date")
+
+	// ErrInvalidReferencePeriod is returned when the reference period of ACT/ACT ICMA is empty or its frequency is not positive
+	ErrInvalidReferencePeriod = fmt.Errorf("daycount: invalid reference period")
+
+	// ErrInvalidConvention is returned when the convention is unknown
+	ErrInvalidConvention = fmt.Errorf("daycount: invalid convention")
+)
+
+// Convention is a day count convention.
+type Convention interface {
+	// Fraction returns the year fraction from start to end as the exact fraction num/den, where den > 0.
+	Fraction(start, end time.Time) (num, den int64, err error)
+
+	// String returns the name of the convention, e.g. "ACT/360".
+	String() string
+}
+
+// Standard is a day count convention that only depends on the start and end dates.
+type Standard uint8
+
+const (
+	// Act360 is the actual number of days divided by 360.
+	Act360 Standard = iota
+
+	// Act365Fixed is the actual number of days divided by 365, also known as ACT/365F.
+	Act365Fixed
+
+	// ActActISDA is the actual number of days in non-leap years divided by 365
+	// plus the actual number of days in leap years divided by 366.
+	ActActISDA
+
+	// Thirty360US is the 30/360 US (NASD) convention: months have 30 days, and the last day of February
+	// counts as the 30th when the period starts on it.
+	Thirty360US
+
+	// Thirty360European is the 30E/360 (Eurobond basis) convention: months have 30 days, and the 31st counts as the 30th.
+	Thirty360European
+)
+
+// String returns the name of the convention.
+func (s Standard) String() string {
+	switch s {
+	case Act360:
+		return "ACT/360"
+	case Act365Fixed:
+		return "ACT/365F"
+	case ActActISDA:
+		return "ACT/ACT ISDA"
+	case Thirty360US:
+		return "30/360 US"
+	case Thirty360European:
+		return "30E/360"
+	default:
+		return fmt.Sprintf("Standard(%d)", s)
+	}
+}
+
+// Fraction returns the year fraction from start to end as the exact fraction num/den.
+//
+// Returns error if:
+//   - end is before start ([ErrInvalidDates])
+//   - s is not a valid convention ([ErrInvalidConvention])
+func (s Standard) Fraction(start, end time.Time) (num, den int64, err error) {
+	if civilDays(end) < civilDays(start) {
+		return 0, 0, ErrInvalidDates
+	}
+
+	switch s {
+	case Act360:
+		return Days(start, end), 360, nil
+	case Act365Fixed:
+		return Days(start, end), 365, nil
+	case ActActISDA:
+		return actActISDA(start, end), 365 * 366, nil
+	case Thirty360US:
+		return thirty360US(start, end), 360, nil
+	case Thirty360European:
+		return thirty360European(start, end), 360, nil
+	default:
+		return 0, 0, ErrInvalidConvention
+	}
+}
+
+// ActActICMA is the ACT/ACT ICMA convention used for coupon bonds: the actual number of days
+// divided by Frequency times the actual number of days of the reference (coupon) period.
+// A full coupon period is always exactly 1/Frequency of a year.
+type ActActICMA struct {
+	// RefStart and RefEnd are the start and end of the coupon period that contains the accrual period.
+	RefStart, RefEnd time.Time
+
+	// Frequency is the number of coupon periods per year.
+	Frequency int
+}
+
+// String returns the name of the convention.
+func (ActActICMA) String() string {
+	return "ACT/ACT ICMA"
+}
+
+// Fraction returns the year fraction from start to end as the exact fraction num/den.
+//
+// Returns error if:
+//   - end is before start ([ErrInvalidDates])
+//   - the reference period is empty or the frequency is not positive ([ErrInvalidReferencePeriod])
+func (c ActActICMA) Fraction(start, end time.Time) (num, den int64, err error) {
+	if civilDays(end) < civilDays(start) {
+		return 0, 0, ErrInvalidDates
+	}
+
+	ref := Days(c.RefStart, c.RefEnd)
+	if ref <= 0 || c.Frequency <= 0 {
+		return 0, 0, ErrInvalidReferencePeriod
+	}
+
+	return Days(start, end), int64(c.Frequency) * ref, nil
+}
+
+// Bus252 is the BUS/252 convention used in Brazil: the number of business days
+// from start (inclusive) to end (exclusive) divided by 252.
+type Bus252 struct {
+	// Calendar tells which days are business days. If nil, [Weekends] is used.
+	Calendar Calendar
+}
+
+// String returns the name of the convention.
+func (Bus252) String() string {
+	return "BUS/252"
+}
+
+// Fraction returns the year fraction from start to end as the exact fraction num/den.
+//
+// Returns error if end is before start ([ErrInvalidDates]).
+func (c Bus252) Fraction(start, end time.Time) (num, den int64, err error) {
+	if civilDays(end) < civilDays(start) {
+		return 0, 0, ErrInvalidDates
+	}
+
+	return BusinessDays(c.Calendar, start, end), 252, nil
+}
+
+// YearFraction returns the year fraction from start to end with the convention c,
+// truncated to the default precision.
+//
+// Example:
+//
+//	YearFraction(Act360, 2024-01-01, 2024-04-01) = 91/360 = 0.2527777777777777777
+func YearFraction(c Convention, start, end time.Time) (udecimal.Decimal, error) {
+	num, den, err := c.Fraction(start, end)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return udecimal.MustFromInt64(num, 0).Div(udecimal.MustFromInt64(den, 0))
+}
+
+// Interest returns principal * rate * (year fraction from start to end), rounded to prec digits
+// after the decimal point with the given rounding mode. The year fraction is not truncated before rounding.
+//
+// Panics if mode is not a valid [udecimal.RoundingMode].
+//
+// Example:
+//
+//	Interest(Act360, 1000000, 0.05, 2024-01-01, 2024-04-01, 2, RoundHalfEven) = 12638.89
+func Interest(c Convention, principal, rate udecimal.Decimal, start, end time.Time, prec uint8, mode udecimal.RoundingMode) (udecimal.Decimal, error) {
+	num, den, err := c.Fraction(start, end)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return principal.Mul(rate).MulDivRound(udecimal.MustFromInt64(num, 0), udecimal.MustFromInt64(den, 0), prec, mode)
+}
+
+// Days returns the actual number of days from start to end, negative if end is before start.
+func Days(start, end time.Time) int64 {
+	return civilDays(end) - civilDays(start)
+}
+
+func actActISDA(start, end time.Time) int64 {
+	// days in non-leap years count 366/(365*366), days in leap years 365/(365*366)
+	var num int64
+
+	for y := start.Year(); y <= end.Year(); y++ {
+		from, to := start, end
+		if y > start.Year() {
+			from = time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+
+		if y < end.Year() {
+			to = time.Date(y+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+
+		if isLeap(y) {
+			num += Days(from, to) * 365
+		} else {
+			num += Days(from, to) * 366
+		}
+	}
+
+	return num
+}
+
+func thirty360US(start, end time.Time) int64 {
+	y1, m1, d1 := start.Date()
+	y2, m2, d2 := end.Date()
+
+	if isLastOfFebruary(y1, m1, d1) {
+		if isLastOfFebruary(y2, m2, d2) {
+			d2 = 30
+		}
+
+		d1 = 30
+	}
+
+	if d2 == 31 && d1 >= 30 {
+		d2 = 30
+	}
+
+	if d1 == 31 {
+		d1 = 30
+	}
+
+	return thirty360(y1, m1, d1, y2, m2, d2)
+}
+
+func thirty360European(start, end time.Time) int64 {
+	y1, m1, d1 := start.Date()
+	y2, m2, d2 := end.Date()
+
+	d1 = min(d1, 30)
+	d2 = min(d2, 30)
+
+	return thirty360(y1, m1, d1, y2, m2, d2)
+}
+
+func thirty360(y1 int, m1 time.Month, d1 int, y2 int, m2 time.Month, d2 int) int64 {
+	return int64(360*(y2-y1) + 30*int(m2-m1) + d2 - d1)
+}
+
+func isLeap(y int) bool {
+	return y%4 == 0 && (y%100 != 0 || y%400 == 0)
+}
+
+func isLastOfFebruary(y int, m time.Month, d int) bool {
+	return m == time.February && (d == 29 || (d == 28 && !isLeap(y)))
+}
+
+// civilDays returns the number of days since 1970-01-01 of the date of t, ignoring the time of day and the time zone
+func civilDays(t time.Time) int64 {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
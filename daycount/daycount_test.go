@@ -0,0 +1,150 @@
+package daycount
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markovichecha/udecimal"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+func TestYearFraction(t *testing.T) {
+	testcases := []struct {
+		start, end           string
+		act360, act365, isda string
+		thirtyUS, thirtyEuro string
+	}{
+		{"2003-11-01", "2004-05-01", "0.5055555555555555555", "0.4986301369863013698", "0.4977243805674077401", "0.5", "0.5"},
+		{"2024-01-01", "2024-04-01", "0.2527777777777777777", "0.2493150684931506849", "0.248633879781420765", "0.25", "0.25"},
+		{"2023-12-15", "2025-03-31", "1.3111111111111111111", "1.2931506849315068493", "1.290410958904109589", "1.2944444444444444444", "1.2916666666666666666"},
+		{"2007-02-28", "2008-02-29", "1.0166666666666666666", "1.0027397260273972602", "1.0022980762033086308", "1", "1.0027777777777777777"},
+		{"2024-01-31", "2024-03-31", "0.1666666666666666666", "0.1643835616438356164", "0.1639344262295081967", "0.1666666666666666666", "0.1666666666666666666"},
+		{"2024-02-29", "2024-03-31", "0.0861111111111111111", "0.0849315068493150684", "0.0846994535519125683", "0.0833333333333333333", "0.0861111111111111111"},
+		{"2024-05-17", "2024-05-17", "0", "0", "0", "0", "0"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.start+"/"+tc.end, func(t *testing.T) {
+			for conv, want := range map[Standard]string{
+				Act360:            tc.act360,
+				Act365Fixed:       tc.act365,
+				ActActISDA:        tc.isda,
+				Thirty360US:       tc.thirtyUS,
+				Thirty360European: tc.thirtyEuro,
+			} {
+				got, err := YearFraction(conv, date(tc.start), date(tc.end))
+				require.NoError(t, err)
+				require.Equal(t, want, got.String(), conv.String())
+			}
+		})
+	}
+}
+
+func TestTimeOfDayIgnored(t *testing.T) {
+	tz := time.FixedZone("UTC+14", 14*3600)
+	start := time.Date(2024, time.January, 1, 23, 59, 0, 0, tz)
+	end := time.Date(2024, time.January, 2, 0, 1, 0, 0, time.UTC)
+
+	num, den, err := Act360.Fraction(start, end)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), num)
+	require.Equal(t, int64(360), den)
+}
+
+func TestActActICMA(t *testing.T) {
+	testcases := []struct {
+		start, end, refStart, refEnd string
+		frequency                    int
+		want                         string
+	}{
+		{"2003-11-01", "2004-05-01", "2003-11-01", "2004-05-01", 2, "0.5"},
+		{"2003-11-01", "2004-02-01", "2003-11-01", "2004-05-01", 2, "0.2527472527472527472"},
+		{"2024-01-15", "2024-04-15", "2024-01-15", "2024-04-15", 4, "0.25"},
+		{"2023-06-30", "2024-06-30", "2023-06-30", "2024-06-30", 1, "1"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.start+"/"+tc.end, func(t *testing.T) {
+			conv := ActActICMA{RefStart: date(tc.refStart), RefEnd: date(tc.refEnd), Frequency: tc.frequency}
+			got, err := YearFraction(conv, date(tc.start), date(tc.end))
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got.String())
+		})
+	}
+}
+
+func TestBus252(t *testing.T) {
+	holidays := NewHolidays(date("2024-01-01"), date("2024-01-06"))
+
+	testcases := []struct {
+		name       string
+		cal        Calendar
+		start, end string
+		want       string
+	}{
+		{"weekends", nil, "2024-01-01", "2024-01-08", "0.0198412698412698412"},
+		{"holidays", holidays, "2024-01-01", "2024-01-08", "0.0158730158730158730"},
+		{"start on weekend", Weekends, "2024-01-06", "2024-01-09", "0.0039682539682539682"},
+		{"same day", nil, "2024-01-02", "2024-01-02", "0"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := YearFraction(Bus252{Calendar: tc.cal}, date(tc.start), date(tc.end))
+			require.NoError(t, err)
+			require.Equal(t, udecimal.MustParse(tc.want), got)
+		})
+	}
+
+	// a full year of weekdays
+	require.Equal(t, int64(262), BusinessDays(nil, date("2024-01-01"), date("2025-01-01")))
+	require.Equal(t, int64(0), BusinessDays(nil, date("2024-01-02"), date("2024-01-01")))
+}
+
+func TestInterest(t *testing.T) {
+	m := udecimal.MustParse
+
+	got, err := Interest(Act360, m("1000000"), m("0.05"), date("2024-01-01"), date("2024-04-01"), 2, udecimal.RoundHalfEven)
+	require.NoError(t, err)
+	require.Equal(t, "12638.89", got.String())
+
+	// the fraction is not truncated: 36000 * 30/360 = 3000
+	got, err = Interest(Act360, m("360000"), m("0.1"), date("2024-04-01"), date("2024-05-01"), 2, udecimal.RoundDown)
+	require.NoError(t, err)
+	require.Equal(t, "3000", got.String())
+}
+
+func TestErrors(t *testing.T) {
+	start, end := date("2024-01-02"), date("2024-01-01")
+
+	for _, conv := range []Convention{Act360, Act365Fixed, ActActISDA, Thirty360US, Thirty360European, Bus252{}, ActActICMA{}} {
+		_, err := YearFraction(conv, start, end)
+		require.ErrorIs(t, err, ErrInvalidDates, conv.String())
+	}
+
+	_, err := YearFraction(Standard(100), end, start)
+	require.ErrorIs(t, err, ErrInvalidConvention)
+	require.Equal(t, "Standard(100)", Standard(100).String())
+
+	_, err = YearFraction(ActActICMA{RefStart: end, RefEnd: end, Frequency: 2}, end, start)
+	require.ErrorIs(t, err, ErrInvalidReferencePeriod)
+
+	_, err = YearFraction(ActActICMA{RefStart: end, RefEnd: start, Frequency: 0}, end, start)
+	require.ErrorIs(t, err, ErrInvalidReferencePeriod)
+
+	_, err = Interest(Act360, udecimal.One, udecimal.One, start, end, 2, udecimal.RoundHalfEven)
+	require.ErrorIs(t, err, ErrInvalidDates)
+
+	_, err = NewAccrual(Act360, 2).Accrue(udecimal.One, udecimal.One, start, end)
+	require.ErrorIs(t, err, ErrInvalidDates)
+}
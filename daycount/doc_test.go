@@ -0,0 +1,43 @@
+package daycount
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/markovichecha/udecimal"
+)
+
+func ExampleYearFraction() {
+	start := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	for _, conv := range []Convention{Act360, Act365Fixed, ActActISDA, Thirty360US, Bus252{}} {
+		f, _ := YearFraction(conv, start, end)
+		fmt.Println(conv, f)
+	}
+	// Output:
+	// ACT/360 0.1666666666666666666
+	// ACT/365F 0.1643835616438356164
+	// ACT/ACT ISDA 0.1639344262295081967
+	// 30/360 US 0.1666666666666666666
+	// BUS/252 0.1706349206349206349
+}
+
+func ExampleAccrual() {
+	principal, rate := udecimal.MustParse("12000"), udecimal.MustParse("0.1")
+	start := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	// 3.333... per day, the fractions of a cent are carried to the next day
+	daily := NewAccrual(Act360, 2)
+	total := udecimal.Zero
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		posted, _ := daily.Accrue(principal, rate, day, day.AddDate(0, 0, 1))
+		total = total.Add(posted)
+	}
+
+	monthly, _ := NewAccrual(Act360, 2).Accrue(principal, rate, start, end)
+	fmt.Println(total, monthly)
+	// Output:
+	// 100 100
+}
@@ -105,15 +105,26 @@ var (
 	ErrMaxStrLen = fmt.Errorf("string input exceeds maximum length %d", maxStrLen)
 
 	// ErrInvalidFormat is returned when the input string is not in the correct format
-	// It doesn't support scientific notation, such as 1e-2, 1.23e4, etc.
+	// Scientific notation, such as 1e-2, 1.23e4, etc. is only supported when enabled
+	// with [Context.WithParseExponent] or ParseOptions.AllowExponent.
 	ErrInvalidFormat = fmt.Errorf("invalid format")
 
+	// ErrExponentOutOfRange is returned when a number in scientific notation has too many digits
+	// before the decimal point to be parsed, e.g. 1e500. See [ErrMaxStrLen].
+	ErrExponentOutOfRange = fmt.Errorf("exponent out of range. The number can't have more than %d characters without exponent", maxStrLen)
+
 	// ErrDivideByZero is returned when dividing by zero
 	ErrDivideByZero = fmt.Errorf("can't divide by zero")
 
 	// ErrSqrtNegative is returned when calculating square root of negative number
 	ErrSqrtNegative = fmt.Errorf("can't calculate square root of negative number")
 
+	// ErrRootNegative is returned when calculating an even root of negative number
+	ErrRootNegative = fmt.Errorf("can't calculate even root of negative number")
+
+	// ErrRootZeroDegree is returned when calculating the 0th root
+	ErrRootZeroDegree = fmt.Errorf("can't calculate 0th root")
+
 	// ErrInvalidBinaryData is returned when unmarshalling invalid binary data
 	// The binary data should follow the format as described in MarshalBinary
 	ErrInvalidBinaryData = fmt.Errorf("invalid binary data")
@@ -154,6 +165,8 @@ type Decimal struct {
 // This function is particularly useful when you want to have your precision of the deicmal smaller than 19
 // across the whole application. It should be called only once at the beginning of your application
 //
+// It's not safe for concurrent use, use a [Context] if different parts of the application need different precisions.
+//
 // Panics if the new precision is greater than 19 (maxPrec) or new precision is 0
 func SetDefaultPrecision(prec uint8) {
 	if prec > maxPrec {
@@ -316,6 +329,7 @@ func (d Decimal) ToHiLo() (neg bool, hi uint64, lo uint64, prec uint8, ok bool)
 
 // Parse parses a number in string to a decimal.
 // The string must be in the format of: [+-]d{1,19}[.d{1,19}]
+// Scientific notation is rejected, use [Context.WithParseExponent] or [ParseWithOptions] to accept it.
 //
 // Returns error if:
 //  1. empty/invalid string
@@ -330,7 +344,7 @@ func ParseBytes(b []byte) (Decimal, error) {
 }
 
 func parseBytes(b []byte) (Decimal, error) {
-	neg, bint, prec, err := parseBint(b)
+	neg, bint, prec, err := parseBintExp(b, defaultPrec, defaultParseMode, false, maxStrLen)
 	if err != nil {
 		return Decimal{}, err
 	}
@@ -565,6 +579,10 @@ func (d Decimal) Div(e Decimal) (Decimal, error) {
 
 	neg := d.neg != e.neg
 
+	// d can have more than defaultPrec + e.prec digits when it's created by a Context,
+	// truncating it first gives the same result: floor(floor(a/b)/c) = floor(a/(b*c))
+	d = d.Trunc(defaultPrec + e.prec)
+
 	q, err := tryDivU128(d, e, neg)
 	if err == nil {
 		return q, nil
@@ -623,6 +641,9 @@ func (d Decimal) Div64(v uint64) (Decimal, error) {
 		return d, nil
 	}
 
+	// d can have more than defaultPrec digits when it's created by a Context
+	d = d.Trunc(defaultPrec)
+
 	if !d.coef.overflow() {
 		d256 := d.coef.u128.MulToU256(pow10[defaultPrec-d.prec])
 		quo, _, err := d256.div192by64(v)
@@ -727,6 +748,62 @@ func (d Decimal) Mod(e Decimal) (Decimal, error) {
 	return r, err
 }
 
+// QuoRemFloor returns q and r where
+// - q = floor(d / e) and q is an integer
+// - r = d - q * e (|r| < |e| and r has the same sign as e)
+//
+// The implementation is similar to Python's divmod function.
+// Returns divide by zero error when e is zero
+func (d Decimal) QuoRemFloor(e Decimal) (Decimal, Decimal, error) {
+	q, r, err := d.QuoRem(e)
+	if err != nil {
+		return Decimal{}, Decimal{}, err
+	}
+
+	// the truncated quotient is one step too close to zero when the remainder and e have different signs
+	if !r.IsZero() && r.neg != e.neg {
+		q = q.Sub(One)
+		r = r.Add(e)
+	}
+
+	return q, r, nil
+}
+
+// QuoRemEuclid returns q and r where
+// - q is an integer
+// - r = d - q * e (0 <= r < |e|)
+//
+// Returns divide by zero error when e is zero
+func (d Decimal) QuoRemEuclid(e Decimal) (Decimal, Decimal, error) {
+	q, r, err := d.QuoRem(e)
+	if err != nil {
+		return Decimal{}, Decimal{}, err
+	}
+
+	if !r.neg {
+		return q, r, nil
+	}
+
+	// r < 0, move it into [0, |e|)
+	if e.neg {
+		return q.Add(One), r.Sub(e), nil
+	}
+
+	return q.Sub(One), r.Add(e), nil
+}
+
+// ModFloor is similar to [Decimal.QuoRemFloor] but only returns the remainder
+func (d Decimal) ModFloor(e Decimal) (Decimal, error) {
+	_, r, err := d.QuoRemFloor(e)
+	return r, err
+}
+
+// ModEuclid is similar to [Decimal.QuoRemEuclid] but only returns the remainder
+func (d Decimal) ModEuclid(e Decimal) (Decimal, error) {
+	_, r, err := d.QuoRemEuclid(e)
+	return r, err
+}
+
 // Prec returns decimal precision as an integer
 func (d Decimal) Prec() int {
 	return int(d.prec)
@@ -1743,6 +1820,10 @@ func (d Decimal) Sqrt() (Decimal, error) {
 		return Decimal{}, ErrSqrtNegative
 	}
 
+	// d can have more than 2*defaultPrec digits when it's created by a Context,
+	// truncating it first gives the same result: floor(sqrt(floor(x))) = floor(sqrt(x))
+	d = d.Trunc(2 * defaultPrec)
+
 	if d.coef.IsZero() {
 		return Zero, nil
 	}
@@ -1778,8 +1859,18 @@ func (d Decimal) sqrtU128() (Decimal, error) {
 	factor := 2*defaultPrec - d.prec
 
 	coef := d.coef.u128.MulToU256(pow10[factor])
+	x, err := sqrtU256(coef)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	return newDecimal(false, bintFromU128(x), defaultPrec), nil
+}
+
+// sqrtU256 returns the integer square root of coef, coef must be less than 2^192
+func sqrtU256(coef u256) (u128, error) {
 	if coef.carry.hi != 0 {
-		return Decimal{}, errOverflow
+		return u128{}, errOverflow
 	}
 
 	//nolint:gosec // 0 <= coef.bitLen() < 256, so it's safe to convert to uint
@@ -1793,21 +1884,114 @@ func (d Decimal) sqrtU128() (Decimal, error) {
 		// calculate x1 = (x + coef/x) / 2
 		y, _, err := coef.fastQuo(x)
 		if err != nil {
-			return Decimal{}, err
+			return u128{}, err
 		}
 
 		x1, err := x.Add(y)
 		if err != nil {
-			return Decimal{}, err
+			return u128{}, err
 		}
 
+		// the sequence is decreasing until it reaches floor(√coef),
+		// stop when it doesn't decrease anymore to avoid oscillating between floor(√coef) and floor(√coef)+1
 		x1 = x1.Rsh(1)
-		if x1.Cmp(x) == 0 {
+		if x1.Cmp(x) >= 0 {
 			break
 		}
 
 		x = x1
 	}
 
-	return newDecimal(false, bintFromU128(x), defaultPrec), nil
+	return x, nil
+}
+
+// Cbrt returns the cube root of d.
+// The result is the exact value truncated to defaultPrec digits after the decimal point.
+//
+// Examples:
+//
+//	Cbrt(8) = 2
+//	Cbrt(-27) = -3
+//	Cbrt(2) = 1.2599210498948731647
+func (d Decimal) Cbrt() Decimal {
+	// odd root never returns error
+	q, _ := d.Root(3)
+	return q
+}
+
+// Root returns the nth root of d.
+// The result is the exact value truncated to defaultPrec digits after the decimal point.
+//
+// Returns error if:
+//   - n == 0 ([ErrRootZeroDegree])
+//   - d < 0 and n == 2 ([ErrSqrtNegative], same as [Decimal.Sqrt])
+//   - d < 0 and n is even and n > 2 ([ErrRootNegative])
+//
+// Examples:
+//
+//	Root(16, 4) = 2
+//	Root(-32, 5) = -2
+//	Root(1.05, 12) = 1.0040741237836483016
+func (d Decimal) Root(n uint32) (Decimal, error) {
+	switch {
+	case n == 0:
+		return Decimal{}, ErrRootZeroDegree
+	case n == 2:
+		return d.Sqrt()
+	case d.neg && n%2 == 0:
+		return Decimal{}, ErrRootNegative
+	case n == 1 || d.coef.IsZero():
+		return d, nil
+	}
+
+	if d.Abs().Cmp(One) == 0 {
+		return d, nil
+	}
+
+	if !d.coef.overflow() {
+		q, err := d.rootU128(n)
+		if err == nil {
+			return newDecimal(d.neg, bintFromU128(q), defaultPrec), nil
+		}
+	}
+
+	// can't determine the result with u128, fallback to big.Int
+	if n <= maxPowExactRoot {
+		// root(d, n) * 10^defaultPrec = root(coef * 10^(n*defaultPrec - prec), n)
+		t := d
+		if int(d.prec) > int(n)*int(defaultPrec) {
+			// d is created by a Context with more digits, floor(root(floor(x))) = floor(root(x))
+			//nolint:gosec // n*defaultPrec < d.prec <= maxPrec, so it's safe to convert to uint8
+			t = d.Trunc(uint8(int(n) * int(defaultPrec)))
+		}
+
+		coef := t.coef.GetBig()
+		coef.Mul(coef, pow10BigInt(int(n)*int(defaultPrec)-int(t.prec)))
+		return newDecimal(d.neg, bintFromBigInt(rootBig(coef, int(n))), defaultPrec), nil
+	}
+
+	q := truncBig(func(s int) (*big.Int, *big.Int) {
+		t := lnBig(d.coef.GetBig(), d.prec, s)
+		v := expBig(t.Quo(t, big.NewInt(int64(n))), s)
+
+		// the error of ln(d)/n (less than 2*10^(-s)) becomes a relative error of the result
+		vErr := new(big.Int).Mul(v, big.NewInt(4))
+		vErr.Quo(vErr, pow10BigInt(s))
+		return v, vErr.Add(vErr, big.NewInt(4))
+	})
+
+	q.neg = d.neg
+	return q, nil
+}
+
+// rootU128 returns the coefficient of |d|^(1/n) with defaultPrec digits after the decimal point, n > 0
+func (d Decimal) rootU128(n uint32) (u128, error) {
+	neg, t, err := d.lnU128()
+	if err != nil {
+		return u128{}, err
+	}
+
+	// |d|^(1/n) = e^(ln|d|/n)
+	t, _ = t.QuoRem64(uint64(n))
+	return expFixedU128(neg, t, u128{lo: lnErrU128/uint64(n) + 2})
 }
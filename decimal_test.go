@@ -1222,6 +1222,64 @@ func TestMod(t *testing.T) {
 	}
 }
 
+func TestQuoRemFloorEuclid(t *testing.T) {
+	testcases := []struct {
+		a, b             string
+		qFloor, rFloor   string
+		qEuclid, rEuclid string
+	}{
+		{"11.234", "1.12", "10", "0.034", "10", "0.034"},
+		{"-11.234", "1.12", "-11", "1.086", "-11", "1.086"},
+		{"11.234", "-1.12", "-11", "-1.086", "-10", "0.034"},
+		{"-11.234", "-1.12", "10", "-0.034", "11", "1.086"},
+		{"-11.2", "1.12", "-10", "0", "-10", "0"},
+		{"0", "-3", "0", "0", "0", "0"},
+		{"7", "3", "2", "1", "2", "1"},
+		{"-7", "3", "-3", "2", "-3", "2"},
+		{"7", "-3", "-3", "-2", "-2", "1"},
+		{"-7", "-3", "2", "-1", "3", "2"},
+		{"-0.5", "86400", "-1", "86399.5", "-1", "86399.5"},
+		{"-1.1234567890123456789", "123456789012345678900", "-1", "123456789012345678898.8765432109876543211", "-1", "123456789012345678898.8765432109876543211"},
+		{"-12345678901234567890123", "1.1234567890123456789", "-10989010900978142640528", "0.6439895503568144592", "-10989010900978142640528", "0.6439895503568144592"},
+		{"12345678901234567890123", "-1.1234567890123456789", "-10989010900978142640528", "-0.6439895503568144592", "-10989010900978142640527", "0.4794672386555312197"},
+		{"-22773757910726981402256170801141121024", "20715693594775826464.768", "-1099348076690522520", "17708874310761169551.36", "-1099348076690522520", "17708874310761169551.36"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s.QuoRemFloor(%s)", tc.a, tc.b), func(t *testing.T) {
+			a := MustParse(tc.a)
+			b := MustParse(tc.b)
+
+			q, r, err := a.QuoRemFloor(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.qFloor, q.String())
+			require.Equal(t, tc.rFloor, r.String())
+
+			r, err = a.ModFloor(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.rFloor, r.String())
+
+			q, r, err = a.QuoRemEuclid(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.qEuclid, q.String())
+			require.Equal(t, tc.rEuclid, r.String())
+
+			r, err = a.ModEuclid(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.rEuclid, r.String())
+
+			// d = q * e + r
+			require.Equal(t, a.String(), q.Mul(b).Add(r).String())
+		})
+	}
+
+	_, _, err := One.QuoRemFloor(Zero)
+	require.Equal(t, ErrDivideByZero, err)
+
+	_, _, err = One.QuoRemEuclid(Zero)
+	require.Equal(t, ErrDivideByZero, err)
+}
+
 func TestCmp(t *testing.T) {
 	testcases := []struct {
 		a, b string
@@ -2720,6 +2778,27 @@ func TestSqrt(t *testing.T) {
 	}
 }
 
+func TestSqrtOscillation(t *testing.T) {
+	// coef*10^(2*defaultPrec-prec) + 1 is a perfect square,
+	// newton's method oscillates between floor(√coef) and floor(√coef)+1
+	testcases := []struct {
+		a    string
+		want string
+	}{
+		{"1.0000000000000000002", "1"},
+		{"0.9999999999999999998", "0.9999999999999999998"},
+		{"99.999999999999999998", "9.9999999999999999998"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.a, func(t *testing.T) {
+			b, err := MustParse(tc.a).Sqrt()
+			require.NoError(t, err)
+			require.Equal(t, tc.want, b.String())
+		})
+	}
+}
+
 func TestRandomSqrt(t *testing.T) {
 	// from 0.1 to 100
 	for i := 1; i <= 1000; i++ {
@@ -2741,6 +2820,87 @@ func TestRandomSqrt(t *testing.T) {
 	}
 }
 
+func TestRoot(t *testing.T) {
+	testcases := []struct {
+		a       string
+		n       uint32
+		want    string
+		wantErr error
+	}{
+		{"8", 0, "", ErrRootZeroDegree},
+		{"-16", 4, "", ErrRootNegative},
+		{"-1", 2, "", ErrSqrtNegative},
+		{"-4", 2, "", ErrSqrtNegative},
+		{"-16", 6, "", ErrRootNegative},
+		{"0", 3, "0", nil},
+		{"1", 12, "1", nil},
+		{"-1", 3, "-1", nil},
+		{"123.456", 1, "123.456", nil},
+		{"2", 2, "1.4142135623730950488", nil},
+		{"8", 3, "2", nil},
+		{"-27", 3, "-3", nil},
+		{"2", 3, "1.2599210498948731647", nil},
+		{"-2", 3, "-1.2599210498948731647", nil},
+		{"0.001", 3, "0.1", nil},
+		{"0.000000000000000001", 3, "0.000001", nil},
+		{"16", 4, "2", nil},
+		{"-32", 5, "-2", nil},
+		{"1.05", 12, "1.0040741237836483016", nil},
+		{"1.05", 365, "1.0001336806171134403", nil},
+		{"0.5", 1000, "0.9993070929904525219", nil},
+		{"1.0000000000000000001", 7, "1", nil},
+		{"1267650600228229401496703205376", 100, "2", nil},
+		{"123456789012345678901234567890.123456789", 3, "4979338592.3477226971099150388", nil},
+		{"1234567890123456789012345678901234567890123456789", 3, "10727659796768462.1673776909867671517", nil},
+		{"99999999999999999999999999999999999999999999999999", math.MaxUint32, "1.0000000268056188289", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("root(%s, %d)", tc.a, tc.n), func(t *testing.T) {
+			a, err := Parse(tc.a)
+			require.NoError(t, err)
+
+			aStr := a.String()
+
+			b, err := a.Root(tc.n)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, b.String())
+
+			// make sure a is immutable
+			require.Equal(t, aStr, a.String())
+
+			if tc.n == 3 {
+				require.Equal(t, tc.want, a.Cbrt().String())
+			}
+		})
+	}
+}
+
+func TestRandomCbrt(t *testing.T) {
+	// from 0.1 to 100
+	for i := 1; i <= 1000; i++ {
+		input := fmt.Sprintf("%f", float64(i)/10)
+
+		a, err := Parse(input)
+		require.NoError(t, err)
+
+		a = a.Cbrt()
+
+		// cross check with shopspring/decimal: a^3 <= input < (a + 10^-19)^3
+		aa := decimal.RequireFromString(a.String())
+		bb := aa.Add(decimal.New(1, -19))
+		in := decimal.RequireFromString(input)
+
+		require.True(t, aa.Mul(aa).Mul(aa).LessThanOrEqual(in))
+		require.True(t, bb.Mul(bb).Mul(bb).GreaterThan(in))
+	}
+}
+
 func TestInt64(t *testing.T) {
 	testcases := []struct {
 		a       string
@@ -18,6 +18,10 @@
 //   - Marshal/UnmarshalJSON
 //   - Marshal/UnmarshalBinary: gob, protobuf
 //   - SQL: The Decimal type implements the sql.Scanner interface, enabling seamless integration with SQL databases.
+//   - fmt: The Decimal type implements the fmt.Formatter interface, so verbs such as %.2f, %e and %g, width and flags
+//     work without converting to float.
+//   - Locales: [Decimal.FormatLocale] and [ParseLocale] write and read numbers with the separators, grouping,
+//     currency symbol and negative pattern of a [Locale], e.g. 1.234.567,89 € or 12,34,567.89.
 //
 // For more details, see the documentation for each method.
 package udecimal
@@ -130,6 +130,22 @@ func ExampleDecimal_Add64() {
 	// 5.23
 }
 
+func ExampleDecimal_AddChecked() {
+	fmt.Println(MustParse("1.23").AddChecked(MustParse("4.56")))
+	fmt.Println(MustParse("340282366920938463463374607431768211455").AddChecked(MustParse("1")))
+	// Output:
+	// 5.79 <nil>
+	// 0 overflow: coefficient doesn't fit into 128 bits
+}
+
+func ExampleDecimal_MulChecked() {
+	fmt.Println(MustParse("1.23").MulChecked(MustParse("4.56")))
+	fmt.Println(MustParse("18446744073709551616").MulChecked(MustParse("18446744073709551616")))
+	// Output:
+	// 5.6088 <nil>
+	// 0 overflow: coefficient doesn't fit into 128 bits
+}
+
 func ExampleDecimal_Ceil() {
 	fmt.Println(MustParse("1.23").Ceil())
 	// Output:
@@ -238,6 +254,34 @@ func ExampleDecimal_Mod() {
 	// 0 can't divide by zero
 }
 
+func ExampleDecimal_QuoRemFloor() {
+	fmt.Println(MustParse("-7").QuoRemFloor(MustParse("3")))
+	fmt.Println(MustParse("7").QuoRemFloor(MustParse("-3")))
+	// Output:
+	// -3 2 <nil>
+	// -3 -2 <nil>
+}
+
+func ExampleDecimal_QuoRemEuclid() {
+	fmt.Println(MustParse("-7").QuoRemEuclid(MustParse("3")))
+	fmt.Println(MustParse("-7").QuoRemEuclid(MustParse("-3")))
+	// Output:
+	// -3 2 <nil>
+	// 3 2 <nil>
+}
+
+func ExampleDecimal_ModFloor() {
+	fmt.Println(MustParse("-1.23").ModFloor(MustParse("0.5")))
+	// Output:
+	// 0.27 <nil>
+}
+
+func ExampleDecimal_ModEuclid() {
+	fmt.Println(MustParse("-1.23").ModEuclid(MustParse("-0.5")))
+	// Output:
+	// 0.27 <nil>
+}
+
 func ExampleDecimal_Sub() {
 	a := MustParse("1.23")
 	b := MustParse("4.12475")
@@ -469,6 +513,84 @@ func ExampleDecimal_PowInt32() {
 	// 0 can't raise zero to a negative power
 }
 
+func ExampleDecimal_Exp() {
+	fmt.Println(MustParse("1").Exp())
+	fmt.Println(MustParse("-2.5").Exp())
+	fmt.Println(MustParse("0").Exp())
+	fmt.Println(MustParse("461").Exp())
+	// Output:
+	// 2.7182818284590452353 <nil>
+	// 0.0820849986238987951 <nil>
+	// 1 <nil>
+	// 0 exponent is out of range. Must be less than or equal 460
+}
+
+func ExampleDecimal_Ln() {
+	fmt.Println(MustParse("2").Ln())
+	fmt.Println(MustParse("0.5").Ln())
+	fmt.Println(MustParse("1").Ln())
+	fmt.Println(MustParse("0").Ln())
+	// Output:
+	// 0.6931471805599453094 <nil>
+	// -0.6931471805599453094 <nil>
+	// 0 <nil>
+	// 0 can't calculate logarithm of zero or negative number
+}
+
+func ExampleDecimal_Log10() {
+	fmt.Println(MustParse("1000").Log10())
+	fmt.Println(MustParse("2").Log10())
+	fmt.Println(MustParse("0.001").Log10())
+	// Output:
+	// 3 <nil>
+	// 0.3010299956639811952 <nil>
+	// -3 <nil>
+}
+
+func ExampleDecimal_Log2() {
+	fmt.Println(MustParse("1024").Log2())
+	fmt.Println(MustParse("3").Log2())
+	fmt.Println(MustParse("0.125").Log2())
+	// Output:
+	// 10 <nil>
+	// 1.5849625007211561814 <nil>
+	// -3 <nil>
+}
+
+func ExampleDecimal_Log() {
+	fmt.Println(MustParse("100").Log(MustParse("3")))
+	fmt.Println(MustParse("8").Log(MustParse("4")))
+	fmt.Println(MustParse("2").Log(MustParse("1")))
+	// Output:
+	// 4.1918065485787692085 <nil>
+	// 1.5 <nil>
+	// 0 logarithm base must be positive and not equal to 1
+}
+
+func ExampleDecimal_Pow() {
+	fmt.Println(MustParse("2").Pow(MustParse("3")))
+	fmt.Println(MustParse("4").Pow(MustParse("0.5")))
+	fmt.Println(MustParse("1.05").Pow(MustParse("0.0821917808219178082")))
+	fmt.Println(MustParse("-2").Pow(MustParse("0.5")))
+	// Output:
+	// 8 <nil>
+	// 2 <nil>
+	// 1.004018201891974921 <nil>
+	// 0 can't raise negative number to a non-integer power
+}
+
+func ExampleDecimal_PowRound() {
+	fmt.Println(MustParse("2").PowRound(MustParse("0.5"), 4, RoundHalfEven))
+	fmt.Println(MustParse("2").PowRound(MustParse("0.5"), 4, RoundCeiling))
+	fmt.Println(MustParse("1.5625").PowRound(MustParse("0.5"), 1, RoundHalfEven))
+	fmt.Println(MustParse("1.5625").PowRound(MustParse("0.5"), 1, RoundHalfUp))
+	// Output:
+	// 1.4142 <nil>
+	// 1.4143 <nil>
+	// 1.2 <nil>
+	// 1.3 <nil>
+}
+
 func ExampleDecimal_Prec() {
 	fmt.Println(MustParse("1.23").Prec())
 	// Output:
@@ -529,6 +651,124 @@ func ExampleDecimal_RoundHTZ() {
 	// -1
 }
 
+func ExampleDecimal_Round() {
+	fmt.Println(MustParse("1.25").Round(1, RoundHalfEven))
+	fmt.Println(MustParse("1.25").Round(1, RoundHalfUp))
+	fmt.Println(MustParse("1.25").Round(1, RoundHalfDown))
+	fmt.Println(MustParse("-1.21").Round(1, RoundFloor))
+	fmt.Println(MustParse("-1.29").Round(1, RoundCeiling))
+	// Output:
+	// 1.2
+	// 1.3
+	// 1.2
+	// -1.3
+	// -1.2
+}
+
+func ExampleDecimal_FloorPrec() {
+	fmt.Println(MustParse("1.129").FloorPrec(2))
+	fmt.Println(MustParse("-1.121").FloorPrec(2))
+	// Output:
+	// 1.12
+	// -1.13
+}
+
+func ExampleDecimal_CeilPrec() {
+	fmt.Println(MustParse("1.121").CeilPrec(2))
+	fmt.Println(MustParse("-1.129").CeilPrec(2))
+	// Output:
+	// 1.13
+	// -1.12
+}
+
+func ExampleDecimal_RoundToIncrement() {
+	fmt.Println(MustParse("1.37").RoundToIncrement(MustParse("0.25"), RoundHalfEven))
+	fmt.Println(MustParse("1.025").RoundToIncrement(MustParse("0.05"), RoundHalfUp))
+	fmt.Println(MustParse("-1.01").RoundToIncrement(MustParse("0.05"), RoundFloor))
+	fmt.Println(MustParse("1.01").RoundToIncrement(MustParse("0"), RoundFloor))
+	// Output:
+	// 1.25 <nil>
+	// 1.05 <nil>
+	// -1.05 <nil>
+	// 0 can't round to a non-positive increment
+}
+
+func ExampleDecimal_IsMultipleOf() {
+	fmt.Println(MustParse("1.75").IsMultipleOf(MustParse("0.25")))
+	fmt.Println(MustParse("1.03").IsMultipleOf(MustParse("0.05")))
+	// Output:
+	// true
+	// false
+}
+
+func ExampleDecimal_RoundSig() {
+	fmt.Println(MustParse("0.000123456").RoundSig(3, RoundHalfEven))
+	fmt.Println(MustParse("123456").RoundSig(3, RoundHalfEven))
+	fmt.Println(MustParse("9.99").RoundSig(2, RoundHalfUp))
+	// Output:
+	// 0.000123
+	// 123000
+	// 10
+}
+
+func ExampleDecimal_NumSignificantDigits() {
+	fmt.Println(MustParse("0.000123").NumSignificantDigits())
+	fmt.Println(MustParse("123000").NumSignificantDigits())
+	fmt.Println(MustParse("100.001").NumSignificantDigits())
+	// Output:
+	// 3
+	// 3
+	// 6
+}
+
+func ExampleDecimal_MulRound() {
+	fmt.Println(MustParse("1.15").MulRound(MustParse("0.5"), 2, RoundHalfEven))
+	fmt.Println(MustParse("1.15").MulRound(MustParse("0.5"), 2, RoundDown))
+	// Output:
+	// 0.58
+	// 0.57
+}
+
+func ExampleDecimal_DivRound() {
+	fmt.Println(MustParse("2").DivRound(MustParse("3"), 2, RoundHalfEven))
+	fmt.Println(MustParse("2").DivRound(MustParse("3"), 2, RoundDown))
+	fmt.Println(MustParse("-1").DivRound(MustParse("8"), 2, RoundHalfEven))
+	fmt.Println(MustParse("1").DivRound(MustParse("0"), 2, RoundHalfEven))
+	// Output:
+	// 0.67 <nil>
+	// 0.66 <nil>
+	// -0.12 <nil>
+	// 0 can't divide by zero
+}
+
+func ExampleDecimal_SqrtRound() {
+	fmt.Println(MustParse("2").SqrtRound(2, RoundHalfEven))
+	fmt.Println(MustParse("2").SqrtRound(2, RoundCeiling))
+	fmt.Println(MustParse("2.25").SqrtRound(0, RoundHalfEven))
+	// Output:
+	// 1.41 <nil>
+	// 1.42 <nil>
+	// 2 <nil>
+}
+
+func ExampleDecimal_MulDiv() {
+	fmt.Println(MustParse("0.0000000000000000005").MulDiv(MustParse("0.5"), MustParse("0.25")))
+	fmt.Println(MustParse("100").MulDiv(MustParse("1"), MustParse("3")))
+	fmt.Println(MustParse("1").MulDiv(MustParse("1"), MustParse("0")))
+	// Output:
+	// 0.000000000000000001 <nil>
+	// 33.3333333333333333333 <nil>
+	// 0 can't divide by zero
+}
+
+func ExampleDecimal_MulDivRound() {
+	fmt.Println(MustParse("100").MulDivRound(MustParse("2"), MustParse("3"), 2, RoundHalfEven))
+	fmt.Println(MustParse("100").MulDivRound(MustParse("2"), MustParse("3"), 2, RoundDown))
+	// Output:
+	// 66.67 <nil>
+	// 66.66 <nil>
+}
+
 func ExampleDecimal_Scan() {
 	var a Decimal
 	_ = a.Scan("1.23")
@@ -557,6 +797,28 @@ func ExampleDecimal_Sqrt() {
 	// 0 can't calculate square root of negative number
 }
 
+func ExampleDecimal_Cbrt() {
+	fmt.Println(MustParse("8").Cbrt())
+	fmt.Println(MustParse("-27").Cbrt())
+	fmt.Println(MustParse("2").Cbrt())
+	// Output:
+	// 2
+	// -3
+	// 1.2599210498948731647
+}
+
+func ExampleDecimal_Root() {
+	fmt.Println(MustParse("16").Root(4))
+	fmt.Println(MustParse("-32").Root(5))
+	fmt.Println(MustParse("1.05").Root(12))
+	fmt.Println(MustParse("-16").Root(4))
+	// Output:
+	// 2 <nil>
+	// -2 <nil>
+	// 1.0040741237836483016 <nil>
+	// 0 can't calculate even root of negative number
+}
+
 func ExampleDecimal_String() {
 	fmt.Println(MustParse("1.23").String())
 	fmt.Println(MustParse("-1.230000").String())
@@ -575,6 +837,75 @@ func ExampleDecimal_StringFixed() {
 	// -1.23000
 }
 
+func ExampleDecimal_Format() {
+	a := MustParse("-1234.5678")
+
+	fmt.Printf("%.2f\n", a)
+	fmt.Printf("[%12.1f]\n", a)
+	fmt.Printf("[%-12v]\n", a)
+	fmt.Printf("%+.3e\n", a.Neg())
+	fmt.Printf("%.3g\n", a)
+	fmt.Printf("%#v\n", a)
+	// Output:
+	// -1234.57
+	// [     -1234.6]
+	// [-1234.5678  ]
+	// +1.235e+03
+	// -1.23e+03
+	// udecimal.MustParse("-1234.5678")
+}
+
+func ExampleDecimal_StringSci() {
+	fmt.Println(MustParse("123.456").StringSci())
+	fmt.Println(MustParse("-0.005").StringSci())
+	fmt.Println(MustParse("1000").StringSci())
+	// Output:
+	// 1.23456e+02
+	// -5e-03
+	// 1e+03
+}
+
+func ExampleDecimal_StringEng() {
+	fmt.Println(MustParse("123456").StringEng())
+	fmt.Println(MustParse("-0.0123").StringEng())
+	fmt.Println(MustParse("0.1").StringEng())
+	// Output:
+	// 123.456e+03
+	// -12.3e-03
+	// 100e-03
+}
+
+func ExampleDecimal_FormatLocale() {
+	a := MustParse("-1234567.8")
+
+	de := MustLookupLocale("de-DE")
+	fmt.Println(a.FormatLocale(de))
+	fmt.Println(a.FormatLocale(de.WithSymbol("€").WithMinFrac(2)))
+	fmt.Println(a.FormatLocale(MustLookupLocale("fr-FR")))
+	fmt.Println(a.FormatLocale(MustLookupLocale("en-IN")))
+	fmt.Println(a.FormatLocale(MustLookupLocale("de-CH")))
+	fmt.Println(a.FormatLocale(MustLookupLocale("en-US").WithSymbol("$").WithNegative(NegativeParens)))
+	// Output:
+	// -1.234.567,8
+	// -1.234.567,80 €
+	// -1 234 567,8
+	// -12,34,567.8
+	// -1'234'567.8
+	// ($1,234,567.8)
+}
+
+func ExampleParseLocale() {
+	fmt.Println(ParseLocale("1.234.567,89", MustLookupLocale("de-DE")))
+	fmt.Println(ParseLocale("(1 234,50 €)", MustLookupLocale("fr-FR").WithSymbol("€")))
+	fmt.Println(ParseLocale("12,34,567.89", MustLookupLocale("en-IN")))
+	fmt.Println(ParseLocale("1.234,5", MustLookupLocale("en-US")))
+	// Output:
+	// 1234567.89 <nil>
+	// -1234.5 <nil>
+	// 1234567.89 <nil>
+	// 0 invalid format: can't parse '1.234,5'
+}
+
 func ExampleDecimal_Trunc() {
 	fmt.Println(MustParse("1.23").Trunc(1))
 	fmt.Println(MustParse("-1.23").Trunc(5))
@@ -632,3 +963,128 @@ func ExampleNullDecimal_Value() {
 	// 1.2345 <nil>
 	// <nil> <nil>
 }
+
+func ExampleContext() {
+	ctx := NewContext(2, RoundHalfEven)
+
+	a := MustParse("1.005")
+	b := MustParse("3")
+
+	fmt.Println(ctx.Add(a, b))
+	fmt.Println(ctx.Mul(a, b))
+	fmt.Println(ctx.Div(a, b))
+	fmt.Println(ctx.Sqrt(b))
+	// Output:
+	// 4 <nil>
+	// 3.02 <nil>
+	// 0.34 <nil>
+	// 1.73 <nil>
+}
+
+func ExampleParseWithOptions() {
+	opts := ParseOptions{
+		AllowUnderscores:   true,
+		TrimSpace:          true,
+		RejectPlus:         true,
+		RejectLeadingZeros: true,
+		Scale:              2,
+		ScaleRule:          ScaleExact,
+		MaxLength:          16,
+	}
+
+	fmt.Println(ParseWithOptions(" 1_000.50\n", opts))
+
+	// error cases
+	fmt.Println(ParseWithOptions("+1000.50", opts))
+	fmt.Println(ParseWithOptions("01000.50", opts))
+	fmt.Println(ParseWithOptions("1000.5", opts))
+	fmt.Println(ParseWithOptions("1__000.50", opts))
+	fmt.Println(ParseWithOptions("10_000_000_000.50", opts))
+	fmt.Println(ParseWithOptions(" 1.50", ParseOptions{}))
+	// Output:
+	// 1000.5 <nil>
+	// 0 leading plus sign is not allowed: can't parse '+1000.50'
+	// 0 leading zeros are not allowed: can't parse '01000.50'
+	// 0 number of digits after the decimal point doesn't match the scale: '1000.5' has 1 digits, want 2
+	// 0 underscores are only allowed between digits with AllowUnderscores: can't parse '1__000.50'
+	// 0 string input exceeds maximum length 16
+	// 0 leading or trailing whitespace is not allowed: can't parse ' 1.50'
+}
+
+func ExampleContext_Parse() {
+	ctx := NewContext(2, RoundHalfEven)
+	fmt.Println(ctx.Parse("1.239"))
+	fmt.Println(ctx.WithParseMode(ParseModeTrunc).Parse("1.239"))
+	// Output:
+	// 0 precision out of range. Only support maximum 2 digits after the decimal point
+	// 1.23 <nil>
+}
+
+func ExampleContext_WithParseExponent() {
+	ctx := DefaultContext().WithParseExponent(true)
+	fmt.Println(ctx.Parse("1.23e4"))
+	fmt.Println(ctx.Parse("-5E-3"))
+	fmt.Println(ctx.Parse("1e-20"))
+	// Output:
+	// 12300 <nil>
+	// -0.005 <nil>
+	// 0 precision out of range. Only support maximum 19 digits after the decimal point
+}
+
+func ExampleContext_WithOverflowPolicy() {
+	ctx := DefaultContext().WithOverflowPolicy(OverflowError)
+	fmt.Println(ctx.Mul(MustParse("18446744073709551616"), MustParse("18446744073709551616")))
+	// Output:
+	// 0 overflow: coefficient doesn't fit into 128 bits
+}
+
+func ExampleAccumulator() {
+	var a, b Accumulator
+	a.Add(MustParse("1.005"))
+	a.Add(MustParse("2.5"))
+	b.Add(MustParse("0.0000000000000000001"))
+	b.Sub(MustParse("0.5"))
+
+	// combine partial sums, e.g. computed by different goroutines
+	a.Merge(b)
+
+	fmt.Println(a.Result(19, RoundHalfEven))
+	fmt.Println(a.Result(2, RoundHalfEven))
+	// Output:
+	// 3.0050000000000000001
+	// 3.01
+}
+
+func ExampleAllocate() {
+	fmt.Println(Allocate(MustParse("100"), []Decimal{MustParse("1"), MustParse("1"), MustParse("1")}, 2))
+	fmt.Println(Allocate(MustParse("10"), []Decimal{MustParse("0.5"), MustParse("0.3"), MustParse("0.2")}, 0))
+	fmt.Println(Allocate(MustParse("1.005"), []Decimal{MustParse("1")}, 2))
+	// Output:
+	// [33.34 33.33 33.33] <nil>
+	// [5 3 2] <nil>
+	// [] can't allocate: amount has more digits after the decimal point than prec
+}
+
+func ExampleAllocateWithStrategy() {
+	ratios := []Decimal{MustParse("1"), MustParse("1"), MustParse("1")}
+	fmt.Println(AllocateWithStrategy(MustParse("0.05"), ratios, 2, AllocateToFirst))
+	fmt.Println(AllocateWithStrategy(MustParse("0.05"), ratios, 2, AllocateToLast))
+	// Output:
+	// [0.02 0.02 0.01] <nil>
+	// [0.01 0.02 0.02] <nil>
+}
+
+func ExampleSplit() {
+	fmt.Println(Split(MustParse("-0.1"), 4, 2))
+	// Output:
+	// [-0.03 -0.03 -0.02 -0.02] <nil>
+}
+
+func ExampleRoundLineItems() {
+	items := []Decimal{MustParse("1.005"), MustParse("1.005"), MustParse("1.005")}
+
+	// rounding each item separately gives 1 + 1 + 1 = 3, but the rounded total is 3.02
+	fmt.Println(RoundLineItems(items, 2, RoundHalfEven))
+	// Output:
+	// [1.01 1.01 1]
+}
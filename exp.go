@@ -0,0 +1,1281 @@
+package udecimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+const (
+	// fastPrec is the number of digits after the decimal point used by the u128 fixed-point
+	// fast path of the transcendental functions (Exp, Ln, Log...).
+	// It leaves 17 guard digits over maxPrec while values up to ~340 still fit into u128.
+	fastPrec = 36
+
+	// lnErrU128 is the upper bound of the absolute error (in units of 10^-fastPrec) of lnU128.
+	lnErrU128 = 512
+
+	// expErrU128 is the upper bound of the relative error (in units of 10^-fastPrec) of expU128.
+	expErrU128 = 1024
+
+	// bigGuardDigits is the number of extra digits used internally by the big.Int functions
+	// so that their results are accurate to less than 1 unit in the last place.
+	bigGuardDigits = 10
+
+	// zivMinPrec and zivMaxPrec are the working precision (in digits) of the first and the last
+	// round of the big.Int fallback. See truncBig for more details.
+	zivMinPrec = 40
+	zivMaxPrec = 160
+
+	// maxExpArg is the largest argument accepted by Exp. exp(460) has 200 digits in its integer part,
+	// which is already unrealistic in financial systems (same reason as maxStrLen).
+	maxExpArg = 460
+
+	// maxPowExactRoot and maxPowExactBits limit the cost of checking if the result of Pow is exact
+	maxPowExactRoot = 64
+	maxPowExactBits = 1 << 14
+)
+
+var (
+	// ln2Fast, ln10Fast and sqrt2Fast are ln(2), ln(10) and sqrt(2) scaled by 10^fastPrec
+	ln2Fast   = u128{hi: 37_575_583_950_764_745, lo: 9_456_716_947_207_598_648}
+	ln10Fast  = u128{hi: 124_823_388_007_844_079, lo: 5_541_036_900_753_882_543}
+	sqrt2Fast = u128{hi: 76_664_670_834_168_704, lo: 1_327_104_860_269_872_414}
+
+	two = MustFromUint64(2, 0)
+	ten = MustFromUint64(10, 0)
+)
+
+var (
+	// ErrLogNonPositive is returned when calculating logarithm of zero or negative number
+	ErrLogNonPositive = fmt.Errorf("can't calculate logarithm of zero or negative number")
+
+	// ErrLogInvalidBase is returned when the logarithm base is not positive or equal to 1
+	ErrLogInvalidBase = fmt.Errorf("logarithm base must be positive and not equal to 1")
+
+	// ErrExpOutOfRange is returned when the argument of Exp is too large
+	ErrExpOutOfRange = fmt.Errorf("exponent is out of range. Must be less than or equal %d", maxExpArg)
+
+	// ErrPowNegativeBase is returned when raising a negative number to a non-integer power
+	ErrPowNegativeBase = fmt.Errorf("can't raise negative number to a non-integer power")
+)
+
+// Exp returns e raised to the power of d (e^d).
+// The result is the exact value truncated to defaultPrec digits after the decimal point.
+//
+// Returns [ErrExpOutOfRange] if d > 460
+//
+// Examples:
+//
+//	Exp(0) = 1
+//	Exp(1) = 2.7182818284590452353
+//	Exp(-1) = 0.3678794411714423215
+func (d Decimal) Exp() (Decimal, error) {
+	if d.coef.IsZero() {
+		return One, nil
+	}
+
+	if !d.neg && d.Cmp(newDecimal(false, bintFromU64(maxExpArg), 0)) > 0 {
+		return Decimal{}, ErrExpOutOfRange
+	}
+
+	// e^d < 10^(-defaultPrec) when d < -defaultPrec * ln(10), which is truncated to zero.
+	// Use 3 instead of ln(10) ~ 2.3026 for a simple and safe bound.
+	if d.neg && d.Cmp(newDecimal(true, bintFromU64(3*uint64(defaultPrec)+1), 0)) < 0 {
+		return Zero, nil
+	}
+
+	if !d.coef.overflow() {
+		q, err := d.expU128()
+		if err == nil {
+			return newDecimal(false, bintFromU128(q), defaultPrec), nil
+		}
+	}
+
+	// can't determine the result with u128, fallback to big.Int
+	return truncBig(func(s int) (*big.Int, *big.Int) {
+		v := expBig(d.scaledBig(s), s)
+		return v, expErrBig(v, s)
+	}), nil
+}
+
+// Ln returns the natural logarithm of d.
+// The result is the exact value truncated to defaultPrec digits after the decimal point.
+//
+// Returns [ErrLogNonPositive] if d <= 0
+//
+// Examples:
+//
+//	Ln(1) = 0
+//	Ln(2) = 0.6931471805599453094
+//	Ln(0.5) = -0.6931471805599453094
+func (d Decimal) Ln() (Decimal, error) {
+	if d.neg || d.coef.IsZero() {
+		return Decimal{}, ErrLogNonPositive
+	}
+
+	if d.Cmp(One) == 0 {
+		return Zero, nil
+	}
+
+	if !d.coef.overflow() {
+		neg, v, err := d.lnU128()
+		if err == nil {
+			q, ok := truncFixed(u256{hi: v.hi, lo: v.lo}, u128{lo: lnErrU128}, defaultPrec)
+			if ok {
+				return newDecimal(neg, bintFromU128(q), defaultPrec), nil
+			}
+		}
+	}
+
+	// can't determine the result with u128, fallback to big.Int
+	return truncBig(func(s int) (*big.Int, *big.Int) {
+		return lnBig(d.coef.GetBig(), d.prec, s), big.NewInt(2)
+	}), nil
+}
+
+// Log10 returns the base 10 logarithm of d.
+// The result is the exact value truncated to defaultPrec digits after the decimal point.
+//
+// Returns [ErrLogNonPositive] if d <= 0
+//
+// Examples:
+//
+//	Log10(1000) = 3
+//	Log10(0.001) = -3
+//	Log10(2) = 0.3010299956639811952
+func (d Decimal) Log10() (Decimal, error) {
+	if d.neg || d.coef.IsZero() {
+		return Decimal{}, ErrLogNonPositive
+	}
+
+	if n, ok := d.log10Exact(); ok {
+		//nolint:gosec // abs(n) >= 0, so it's safe to convert to uint64
+		return newDecimal(n < 0, bintFromU64(uint64(abs(n))), 0), nil
+	}
+
+	return d.log(ten, false, ln10Fast, 1), nil
+}
+
+// Log2 returns the base 2 logarithm of d.
+// The result is the exact value truncated to defaultPrec digits after the decimal point.
+//
+// Returns [ErrLogNonPositive] if d <= 0
+//
+// Examples:
+//
+//	Log2(8) = 3
+//	Log2(0.125) = -3
+//	Log2(10) = 3.3219280948873623478
+func (d Decimal) Log2() (Decimal, error) {
+	if d.neg || d.coef.IsZero() {
+		return Decimal{}, ErrLogNonPositive
+	}
+
+	if n, ok := d.log2Exact(); ok {
+		//nolint:gosec // abs(n) >= 0, so it's safe to convert to uint64
+		return newDecimal(n < 0, bintFromU64(uint64(abs(n))), 0), nil
+	}
+
+	return d.log(two, false, ln2Fast, 1), nil
+}
+
+// Log returns the logarithm of d with the given base.
+// The result is the exact value truncated to defaultPrec digits after the decimal point.
+//
+// Returns error if:
+//   - d <= 0 ([ErrLogNonPositive])
+//   - base <= 0 or base == 1 ([ErrLogInvalidBase])
+//
+// Examples:
+//
+//	Log(8, 2) = 3
+//	Log(2, 4) = 0.5
+//	Log(100, 3) = 4.1918065485787692085
+func (d Decimal) Log(base Decimal) (Decimal, error) {
+	if base.neg || base.coef.IsZero() || base.Cmp(One) == 0 {
+		return Decimal{}, ErrLogInvalidBase
+	}
+
+	if d.neg || d.coef.IsZero() {
+		return Decimal{}, ErrLogNonPositive
+	}
+
+	switch {
+	case base.Cmp(ten) == 0:
+		return d.Log10()
+	case base.Cmp(two) == 0:
+		return d.Log2()
+	case d.Cmp(One) == 0:
+		return Zero, nil
+	}
+
+	if !base.coef.overflow() {
+		neg, lb, err := base.lnU128()
+		if err == nil {
+			return d.log(base, neg, lb, lnErrU128), nil
+		}
+	}
+
+	return d.log(base, false, u128{}, 0), nil
+}
+
+// Pow returns d raised to the power of e (d^e), where e can be any decimal.
+//
+// If e is an integer, the result is the same as [Decimal.PowToIntPart].
+// Otherwise, d^e = Exp(e * Ln(d)) and the result is the exact value truncated to defaultPrec digits
+// after the decimal point. Use [Decimal.PowRound] to round the exact value instead.
+//
+// Returns error if:
+//   - d is zero and e is negative ([ErrZeroPowNegative])
+//   - d is negative and e is not an integer ([ErrPowNegativeBase])
+//   - e is not an integer and e * Ln(d) > 460 ([ErrExpOutOfRange])
+//   - e is an integer and |e| > [math.MaxInt32] ([ErrExponentTooLarge])
+//
+// Examples:
+//
+//	Pow(2, 3) = 8
+//	Pow(4, 0.5) = 2
+//	Pow(2, 0.5) = 1.4142135623730950488
+//	Pow(1.05, 0.0821917808219178082) = 1.004018201891974921
+func (d Decimal) Pow(e Decimal) (Decimal, error) {
+	if e.trimTrailingZeros().prec == 0 {
+		return d.PowToIntPart(e)
+	}
+
+	if d.neg {
+		return Decimal{}, ErrPowNegativeBase
+	}
+
+	if d.coef.IsZero() {
+		if e.neg {
+			return Decimal{}, ErrZeroPowNegative
+		}
+
+		return Zero, nil
+	}
+
+	if d.Cmp(One) == 0 {
+		return One, nil
+	}
+
+	if !d.coef.overflow() && !e.coef.overflow() {
+		q, err := d.powU128(e)
+		if err == nil {
+			return newDecimal(false, bintFromU128(q), defaultPrec), nil
+		}
+	}
+
+	// the result can't be determined when it lies on the truncation boundary,
+	// check if it's exact before falling back to the expensive big.Int approximation
+	if q, ok := d.powExact(e); ok {
+		return q, nil
+	}
+
+	// can't determine the result with u128, fallback to big.Int
+	tiny, err := d.powRangeBig(e)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	// same as Exp, the result is truncated to zero
+	if tiny {
+		return Zero, nil
+	}
+
+	return truncBig(d.powBig(e)), nil
+}
+
+// PowRound returns d raised to the power of e rounded to prec digits after the decimal point using the given rounding mode.
+// Unlike Pow, the exact result is rounded directly instead of being truncated to defaultPrec first,
+// so half-way cases such as 1.5625^0.5 = 1.25 are rounded correctly.
+// If prec > defaultPrec, defaultPrec is used.
+//
+// Returns the same errors as Pow.
+//
+// Panics if mode is not a valid [RoundingMode].
+//
+// Examples:
+//
+//	PowRound(2, 0.5, 4, RoundHalfEven) = 1.4142
+//	PowRound(2, 0.5, 4, RoundCeiling) = 1.4143
+//	PowRound(1.5625, 0.5, 1, RoundHalfEven) = 1.2
+//	PowRound(1.5625, 0.5, 1, RoundHalfUp) = 1.3
+//	PowRound(1.05, -3, 2, RoundHalfEven) = 0.86
+func (d Decimal) PowRound(e Decimal, prec uint8, mode RoundingMode) (Decimal, error) {
+	if !mode.valid() {
+		panic(fmt.Sprintf("can't round: invalid rounding mode %d", mode))
+	}
+
+	prec = min(prec, defaultPrec)
+
+	if e.trimTrailingZeros().prec == 0 {
+		return d.powIntRound(e, prec, mode)
+	}
+
+	if d.neg {
+		return Decimal{}, ErrPowNegativeBase
+	}
+
+	if d.coef.IsZero() {
+		if e.neg {
+			return Decimal{}, ErrZeroPowNegative
+		}
+
+		return Zero, nil
+	}
+
+	if d.Cmp(One) == 0 {
+		return One, nil
+	}
+
+	if !d.coef.overflow() && !e.coef.overflow() {
+		v, vErr, err := d.powFixedU128(e)
+		if err == nil {
+			if q, ok := roundFixed(v, vErr, prec, mode); ok {
+				return newDecimal(false, bintFromU128(q), prec), nil
+			}
+		}
+	}
+
+	// the result can't be determined when it lies on a rounding boundary, e.g. exactly half-way
+	if q, ok := d.powExact(e); ok {
+		return q.Round(prec, mode), nil
+	}
+
+	// can't determine the result with u128, fallback to big.Int
+	tiny, err := d.powRangeBig(e)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	// 0 < d^e < 10^(-prec-1), the discarded part is less than a half
+	if tiny {
+		if mode.roundUp(false, 0, -1) {
+			return newDecimal(false, bintFromU64(1), prec), nil
+		}
+
+		return Zero, nil
+	}
+
+	return roundBig(d.powBig(e), prec, mode), nil
+}
+
+// powIntRound returns d^int(e) rounded to prec digits after the decimal point, e must be an integer.
+// The power of the coefficient is exact, so it's rounded with a single division.
+func (d Decimal) powIntRound(e Decimal, prec uint8, mode RoundingMode) (Decimal, error) {
+	if d.coef.IsZero() && e.neg {
+		return Decimal{}, ErrZeroPowNegative
+	}
+
+	eInt := e.Trunc(0)
+	if eInt.coef.overflow() || eInt.coef.u128.Cmp64(math.MaxInt32) > 0 {
+		return Decimal{}, ErrExponentTooLarge
+	}
+
+	//nolint:gosec // u128.lo is already checked to be less than math.MaxInt32
+	n := int(eInt.coef.u128.lo)
+	if n == 0 {
+		return One, nil
+	}
+
+	dTrim := d.trimTrailingZeros()
+	neg := dTrim.neg && n%2 == 1
+
+	// d^n = coef^n / 10^scale
+	p := new(big.Int).Exp(dTrim.coef.GetBig(), big.NewInt(int64(n)), nil)
+	scale := int(dTrim.prec) * n
+
+	var q, r, v *big.Int
+	switch {
+	case e.neg:
+		// d^(-n) = 10^scale / coef^n
+		v = p
+		q, r = new(big.Int).QuoRem(pow10BigInt(scale+int(prec)), v, new(big.Int))
+	case scale <= int(prec):
+		//nolint:gosec // scale <= prec <= maxPrec, so it's safe to convert to uint8
+		return newDecimal(neg, bintFromBigInt(p), uint8(scale)), nil
+	default:
+		v = pow10BigInt(scale - int(prec))
+		q, r = new(big.Int).QuoRem(p, v, new(big.Int))
+	}
+
+	return newDecimal(neg, bintFromBigInt(roundQuoBig(neg, q, r, v, mode)), prec), nil
+}
+
+// powRangeBig checks the range of d^e with big.Int, d must be positive.
+// Returns [ErrExpOutOfRange] if e*ln(d) > 460, or true if d^e is less than 10^(-3*defaultPrec).
+func (d Decimal) powRangeBig(e Decimal) (bool, error) {
+	t := powArgBig(d, e, zivMinPrec/4)
+	limit := pow10BigInt(zivMinPrec / 4)
+
+	// t has an absolute error less than 2 units in the last place
+	if new(big.Int).Sub(t, big.NewInt(2)).Cmp(new(big.Int).Mul(limit, big.NewInt(maxExpArg))) > 0 {
+		return false, ErrExpOutOfRange
+	}
+
+	return new(big.Int).Add(t, big.NewInt(2)).Cmp(new(big.Int).Mul(limit, big.NewInt(-3*int64(defaultPrec)-1))) < 0, nil
+}
+
+// powBig returns the approximation of d^e used by truncBig and roundBig, d must be positive
+func (d Decimal) powBig(e Decimal) func(s int) (*big.Int, *big.Int) {
+	return func(s int) (*big.Int, *big.Int) {
+		v := expBig(powArgBig(d, e, s), s)
+
+		// the error of the argument (less than 2*10^(-s)) becomes a relative error of the result
+		vErr := new(big.Int).Abs(v)
+		vErr.Mul(vErr, big.NewInt(4))
+		vErr.Quo(vErr, pow10BigInt(s))
+		return v, vErr.Add(vErr, big.NewInt(4))
+	}
+}
+
+// powU128 returns the coefficient of d^e = e^(e*ln(d)) with defaultPrec digits after the decimal point.
+// d must be positive, d and e coefficients must fit into u128.
+func (d Decimal) powU128(e Decimal) (u128, error) {
+	v, vErr, err := d.powFixedU128(e)
+	if err != nil {
+		return u128{}, err
+	}
+
+	q, ok := truncFixed(v, vErr, defaultPrec)
+	if !ok {
+		return u128{}, errOverflow
+	}
+
+	return q, nil
+}
+
+// powFixedU128 returns d^e = e^(e*ln(d)) scaled by 10^fastPrec together with its absolute error.
+// d must be positive, d and e coefficients must fit into u128.
+func (d Decimal) powFixedU128(e Decimal) (u256, u128, error) {
+	lxNeg, lx, err := d.lnU128()
+	if err != nil {
+		return u256{}, u128{}, err
+	}
+
+	// t = |e| * |ln(d)|
+	t, _, err := lx.MulToU256(e.coef.u128).fastQuo(pow10[e.prec])
+	if err != nil {
+		return u256{}, u128{}, err
+	}
+
+	// the absolute error of t is less than |e| * lnErrU128 + 1
+	tErr, err := e.coef.u128.Mul64(lnErrU128)
+	if err != nil {
+		return u256{}, u128{}, err
+	}
+
+	tErr, _, err = tErr.QuoRem(pow10[e.prec])
+	if err != nil {
+		return u256{}, u128{}, err
+	}
+
+	tErr, err = tErr.Add64(2)
+	if err != nil {
+		return u256{}, u128{}, err
+	}
+
+	return expFixedApproxU128(lxNeg != e.neg, t, tErr)
+}
+
+// log returns log(d) with the given base, where lbNeg and lb are the sign and the value of ln(base)
+// (scaled by 10^fastPrec) with an absolute error up to lbErr.
+// The fast path is skipped if lb is zero.
+func (d Decimal) log(base Decimal, lbNeg bool, lb u128, lbErr uint64) Decimal {
+	if !d.coef.overflow() && !lb.IsZero() {
+		q, err := d.logU128(lbNeg, lb, lbErr)
+		if err == nil {
+			return q
+		}
+	}
+
+	// can't determine the result with u128, fallback to big.Int
+	return truncBig(func(s int) (*big.Int, *big.Int) {
+		return logBig(d, base, s)
+	})
+}
+
+func (d Decimal) logU128(lbNeg bool, lb u128, lbErr uint64) (Decimal, error) {
+	lxNeg, lx, err := d.lnU128()
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	// q = lx / lb
+	q, _, err := lx.MulToU256(pow10[fastPrec]).fastQuo(lb)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	// The absolute error of q is less than (errX + |q|*errB) / |lb| + 1,
+	// which is bounded by max(errX, errB) * (int(q)+2) * (int(1/|lb|)+1).
+	// When |lb| is small, the error is amplified too much and the result can't be determined.
+	qInt, _, err := q.QuoRem(pow10[fastPrec])
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	lbInv, _, err := pow10[fastPrec].MulToU256(pow10[fastPrec]).fastQuo(lb)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	lbInv, _, err = lbInv.QuoRem(pow10[fastPrec])
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	qErr, err := qInt.Add64(2)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	qErr, err = qErr.Mul64(max(lnErrU128, lbErr))
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	qErr, err = qErr.Mul(u128{lo: lbInv.lo + 1})
+	if err != nil || lbInv.hi != 0 {
+		return Decimal{}, errOverflow
+	}
+
+	coef, ok := truncFixed(u256{hi: q.hi, lo: q.lo}, qErr, defaultPrec)
+	if !ok {
+		return Decimal{}, errOverflow
+	}
+
+	return newDecimal(lxNeg != lbNeg, bintFromU128(coef), defaultPrec), nil
+}
+
+// log10Exact returns n if d = 10^n
+func (d Decimal) log10Exact() (int, bool) {
+	var digits int
+
+	if !d.coef.overflow() {
+		digits = -1
+		for i := range pow10 {
+			if d.coef.u128.Cmp(pow10[i]) == 0 {
+				digits = i
+				break
+			}
+		}
+
+		if digits < 0 {
+			return 0, false
+		}
+	} else {
+		s := d.coef.bigInt.String()
+		for i := 1; i < len(s); i++ {
+			if s[i] != '0' {
+				return 0, false
+			}
+		}
+
+		if s[0] != '1' {
+			return 0, false
+		}
+
+		digits = len(s) - 1
+	}
+
+	return digits - int(d.prec), true
+}
+
+// log2Exact returns n if d = 2^n
+func (d Decimal) log2Exact() (int, bool) {
+	dTrim := d.trimTrailingZeros()
+
+	if dTrim.prec != 0 {
+		// d = 2^(-n) = 5^n / 10^n
+		if dTrim.coef.overflow() || dTrim.coef.u128.Cmp64(pow5(dTrim.prec)) != 0 {
+			return 0, false
+		}
+
+		return -int(dTrim.prec), true
+	}
+
+	if dTrim.coef.overflow() {
+		b := dTrim.coef.bigInt
+		n := b.BitLen() - 1
+
+		//nolint:gosec // n >= 0, so it's safe to convert to uint
+		if b.TrailingZeroBits() != uint(n) {
+			return 0, false
+		}
+
+		return n, true
+	}
+
+	coef := dTrim.coef.u128
+	one, _ := coef.Sub(one128)
+	if coef.hi&one.hi != 0 || coef.lo&one.lo != 0 {
+		return 0, false
+	}
+
+	return coef.bitLen() - 1, true
+}
+
+// lnU128 returns the sign and the absolute value of ln(d) scaled by 10^fastPrec.
+// The absolute error of the result is less than lnErrU128.
+// d must be positive and its coefficient must fit into u128.
+func (d Decimal) lnU128() (bool, u128, error) {
+	// d = coef / 10^prec = m * 2^k / 10^prec
+	// --> ln(d) = k*ln(2) + ln(m) - prec*ln(10)
+	coef := d.coef.u128
+
+	//nolint:gosec // 0 < coef.bitLen() <= 128, so it's safe to convert to uint
+	k := uint64(coef.bitLen() - 1)
+
+	// m = coef / 2^k in [1, 2)
+	m256 := coef.MulToU256(pow10[fastPrec]).rsh(uint(k))
+	m := u128{hi: m256.hi, lo: m256.lo}
+
+	// move m into [sqrt(2)/2, sqrt(2)] for faster convergence
+	if m.Cmp(sqrt2Fast) > 0 {
+		m = m.Rsh(1)
+		k++
+	}
+
+	// ln(m) = 2*atanh(z) with z = (m-1)/(m+1) and |z| <= 0.1716
+	var (
+		diff u128
+		zNeg bool
+		err  error
+	)
+
+	if m.Cmp(pow10[fastPrec]) >= 0 {
+		diff, err = m.Sub(pow10[fastPrec])
+	} else {
+		zNeg = true
+		diff, err = pow10[fastPrec].Sub(m)
+	}
+
+	if err != nil {
+		return false, u128{}, err
+	}
+
+	sum, err := m.Add(pow10[fastPrec])
+	if err != nil {
+		return false, u128{}, err
+	}
+
+	z, _, err := diff.MulToU256(pow10[fastPrec]).fastQuo(sum)
+	if err != nil {
+		return false, u128{}, err
+	}
+
+	lnm, err := atanhU128(z)
+	if err != nil {
+		return false, u128{}, err
+	}
+
+	lnm = lnm.Lsh(1)
+
+	pos, err := ln2Fast.Mul64(k)
+	if err != nil {
+		return false, u128{}, err
+	}
+
+	neg, err := ln10Fast.Mul64(uint64(d.prec))
+	if err != nil {
+		return false, u128{}, err
+	}
+
+	if zNeg {
+		neg, err = neg.Add(lnm)
+	} else {
+		pos, err = pos.Add(lnm)
+	}
+
+	if err != nil {
+		return false, u128{}, err
+	}
+
+	if pos.Cmp(neg) >= 0 {
+		v, err := pos.Sub(neg)
+		return false, v, err
+	}
+
+	v, err := neg.Sub(pos)
+	return true, v, err
+}
+
+// atanhU128 returns atanh(z) = z + z^3/3 + z^5/5 + ... where z is scaled by 10^fastPrec and z < 1
+func atanhU128(z u128) (u128, error) {
+	z2, err := mulFixed(z, z)
+	if err != nil {
+		return u128{}, err
+	}
+
+	sum, term := z, z
+	for i := uint64(3); ; i += 2 {
+		term, err = mulFixed(term, z2)
+		if err != nil {
+			return u128{}, err
+		}
+
+		if term.IsZero() {
+			break
+		}
+
+		t, _ := term.QuoRem64(i)
+		sum, err = sum.Add(t)
+		if err != nil {
+			return u128{}, err
+		}
+	}
+
+	return sum, nil
+}
+
+// expU128 returns the coefficient of e^d with defaultPrec digits after the decimal point.
+// Returns errOverflow if the coefficient doesn't fit into u128 or the result can't be determined.
+func (d Decimal) expU128() (u128, error) {
+	x, err := d.coef.u128.Mul(pow10[fastPrec-d.prec])
+	if err != nil {
+		return u128{}, err
+	}
+
+	return expFixedU128(d.neg, x, u128{})
+}
+
+// expFixedU128 returns the coefficient of e^x (or e^(-x) if neg is true) with defaultPrec digits
+// after the decimal point, where x is scaled by 10^fastPrec and has an absolute error up to xErr.
+// Returns errOverflow if the coefficient doesn't fit into u128 or the result can't be determined.
+func expFixedU128(neg bool, x, xErr u128) (u128, error) {
+	v, vErr, err := expFixedApproxU128(neg, x, xErr)
+	if err != nil {
+		return u128{}, err
+	}
+
+	q, ok := truncFixed(v, vErr, defaultPrec)
+	if !ok {
+		return u128{}, errOverflow
+	}
+
+	return q, nil
+}
+
+// expFixedApproxU128 returns e^x (or e^(-x) if neg is true) scaled by 10^fastPrec together with its absolute error,
+// where x is scaled by 10^fastPrec and has an absolute error up to xErr.
+func expFixedApproxU128(neg bool, x, xErr u128) (u256, u128, error) {
+	// |d| = k*ln(2) + r with |r| <= ln(2)/2
+	// --> e^|d| = 2^k * e^r
+	kq, rem, err := x.QuoRem(ln2Fast)
+	if err != nil {
+		return u256{}, u128{}, err
+	}
+
+	var rNeg bool
+	if rem.Cmp(ln2Fast.Rsh(1)) > 0 {
+		kq, err = kq.Add64(1)
+		if err != nil {
+			return u256{}, u128{}, err
+		}
+
+		rem, _ = ln2Fast.Sub(rem)
+		rNeg = true
+	}
+
+	if kq.hi != 0 || kq.lo > 100 {
+		// the error is too large, can't determine the result
+		return u256{}, u128{}, errOverflow
+	}
+
+	//nolint:gosec // kq <= 100, so it's safe to convert to uint
+	k := uint(kq.lo)
+
+	// e^(-|x|) = 2^(-k) * e^(-r)
+	if neg {
+		rNeg = !rNeg
+	}
+
+	er, err := expTaylorU128(rem, rNeg)
+	if err != nil {
+		return u256{}, u128{}, err
+	}
+
+	// an error of x is amplified by e^r <= sqrt(2) < 2
+	erErr, err := xErr.Lsh(1).Add64(expErrU128)
+	if err != nil || xErr.hi>>63 != 0 {
+		return u256{}, u128{}, errOverflow
+	}
+
+	var (
+		v    u256
+		vErr u128
+	)
+
+	if neg {
+		v = u256{hi: er.hi, lo: er.lo}.rsh(k)
+		vErr, _ = erErr.Rsh(k).Add64(1)
+	} else {
+		//nolint:gosec // 0 < erErr.bitLen() <= 128, so it's safe to convert to uint
+		if uint(erErr.bitLen())+k >= 128 {
+			return u256{}, u128{}, errOverflow
+		}
+
+		v = er.MulToU256(one128.Lsh(k))
+		vErr = erErr.Lsh(k)
+	}
+
+	return v, vErr, nil
+}
+
+// expTaylorU128 returns e^r (or e^(-r) if neg is true) scaled by 10^fastPrec, where r <= ln(2)/2
+func expTaylorU128(r u128, neg bool) (u128, error) {
+	var err error
+
+	sum, term := pow10[fastPrec], pow10[fastPrec]
+	for i := uint64(1); ; i++ {
+		term, err = mulFixed(term, r)
+		if err != nil {
+			return u128{}, err
+		}
+
+		term, _ = term.QuoRem64(i)
+		if term.IsZero() {
+			break
+		}
+
+		if neg && i%2 == 1 {
+			sum, err = sum.Sub(term)
+		} else {
+			sum, err = sum.Add(term)
+		}
+
+		if err != nil {
+			return u128{}, err
+		}
+	}
+
+	return sum, nil
+}
+
+// mulFixed returns a*b where a, b and the result are scaled by 10^fastPrec
+func mulFixed(a, b u128) (u128, error) {
+	q, _, err := a.MulToU256(b).fastQuo(pow10[fastPrec])
+	return q, err
+}
+
+// truncFixed truncates v (scaled by 10^fastPrec) to prec digits after the decimal point.
+// Returns false if the truncated result can't be determined because v has an absolute error up to vErr.
+func truncFixed(v u256, vErr u128, prec uint8) (u128, bool) {
+	factor := pow10[fastPrec-prec]
+
+	q, r, err := v.fastQuo(factor)
+	if err != nil {
+		return u128{}, false
+	}
+
+	if r.Cmp(vErr) < 0 {
+		return u128{}, false
+	}
+
+	upper, err := r.Add(vErr)
+	if err != nil || upper.Cmp(factor) >= 0 {
+		return u128{}, false
+	}
+
+	return q, true
+}
+
+// roundFixed rounds v (scaled by 10^fastPrec) to prec digits after the decimal point, v must be positive.
+// Returns false if the rounded result can't be determined because v has an absolute error up to vErr.
+func roundFixed(v u256, vErr u128, prec uint8, mode RoundingMode) (u128, bool) {
+	factor := pow10[fastPrec-prec]
+
+	q, r, err := v.fastQuo(factor)
+	if err != nil {
+		return u128{}, false
+	}
+
+	// the discarded part must be in (0, factor) and on one side of the half
+	upper, err := r.Add(vErr)
+	if err != nil || r.Cmp(vErr) <= 0 || upper.Cmp(factor) >= 0 {
+		return u128{}, false
+	}
+
+	half := factor.Rsh(1)
+
+	var cmpHalf int
+	switch lower, _ := r.Sub(vErr); {
+	case upper.Cmp(half) < 0:
+		cmpHalf = -1
+	case lower.Cmp(half) > 0:
+		cmpHalf = 1
+	default:
+		return u128{}, false
+	}
+
+	_, digit := q.QuoRem64(10)
+	if mode.roundUp(false, digit, cmpHalf) {
+		q, err = q.Add64(1)
+		if err != nil {
+			return u128{}, false
+		}
+	}
+
+	return q, true
+}
+
+// truncBig returns the exact result of a function truncated to defaultPrec digits after the decimal point.
+//
+// f(s) returns an approximation of the result scaled by 10^s together with its absolute error bound.
+// The precision s is increased until all values within the error bound are truncated to the same result
+// (https://en.wikipedia.org/wiki/Rounding#Table-maker's_dilemma).
+func truncBig(f func(s int) (*big.Int, *big.Int)) Decimal {
+	var (
+		lower, upper *big.Int
+		extra        int
+	)
+
+	for s := zivMinPrec; s <= zivMaxPrec; s *= 2 {
+		v, vErr := f(s + extra)
+		factor := pow10BigInt(s + extra - int(defaultPrec))
+
+		lower = new(big.Int).Sub(v, vErr)
+		lower.Quo(lower, factor)
+
+		upper = new(big.Int).Add(v, vErr)
+		upper.Quo(upper, factor)
+
+		if lower.Cmp(upper) == 0 {
+			return newDecimal(lower.Sign() < 0, bintFromBigInt(lower.Abs(lower)), defaultPrec)
+		}
+
+		// The error bound can be relative to the result (e.g. Exp), so large results need more digits.
+		// Add the number of digits in the integer part of the result to the working precision.
+		if extra == 0 {
+			extra = max(len(new(big.Int).Abs(v).String())-s, 0)
+		}
+	}
+
+	// The result is still undetermined with zivMaxPrec digits, which happens when the exact result lies on
+	// the truncation boundary, e.g. Log(2, 4) = 0.5. The boundary is the value with the larger magnitude.
+	if lower.CmpAbs(upper) > 0 {
+		upper = lower
+	}
+
+	return newDecimal(upper.Sign() < 0, bintFromBigInt(upper.Abs(upper)), defaultPrec)
+}
+
+// roundBig returns the exact result of a function rounded to prec digits after the decimal point, like truncBig.
+// The precision s is increased until all values within the error bound are rounded to the same result.
+func roundBig(f func(s int) (*big.Int, *big.Int), prec uint8, mode RoundingMode) Decimal {
+	var (
+		v, factor *big.Int
+		extra     int
+	)
+
+	for s := zivMinPrec; s <= zivMaxPrec; s *= 2 {
+		var vErr *big.Int
+		v, vErr = f(s + extra)
+		factor = pow10BigInt(s + extra - int(prec))
+
+		// rounding is monotonic, so all values within the error bound are rounded like both ends
+		lower := roundScaledBig(new(big.Int).Sub(v, vErr), factor, mode)
+		upper := roundScaledBig(new(big.Int).Add(v, vErr), factor, mode)
+		if lower.Cmp(upper) == 0 {
+			return newDecimal(lower.Sign() < 0, bintFromBigInt(lower.Abs(lower)), prec)
+		}
+
+		if extra == 0 {
+			extra = max(len(new(big.Int).Abs(v).String())-s, 0)
+		}
+	}
+
+	// The result is still undetermined with zivMaxPrec digits, which happens when the exact result lies on
+	// a rounding boundary: a value with prec digits, or the half-way point between two of them.
+	// Both are multiples of half a unit, round the nearest one.
+	half := new(big.Int).Rsh(factor, 1)
+	b := new(big.Int).Add(v, new(big.Int).Rsh(half, 1))
+	b.Div(b, half)
+	b.Mul(b, half)
+
+	q := roundScaledBig(b, factor, mode)
+	return newDecimal(q.Sign() < 0, bintFromBigInt(q.Abs(q)), prec)
+}
+
+// roundScaledBig returns x / factor rounded with the given mode
+func roundScaledBig(x, factor *big.Int, mode RoundingMode) *big.Int {
+	neg := x.Sign() < 0
+
+	q, r := new(big.Int).QuoRem(new(big.Int).Abs(x), factor, new(big.Int))
+	q = roundQuoBig(neg, q, r, factor, mode)
+	if neg {
+		q.Neg(q)
+	}
+
+	return q
+}
+
+// scaledBig returns d scaled by 10^s as a signed big.Int, s must be greater than or equal d.prec
+func (d Decimal) scaledBig(s int) *big.Int {
+	x := d.coef.GetBig()
+	x.Mul(x, pow10BigInt(s-int(d.prec)))
+	if d.neg {
+		x.Neg(x)
+	}
+
+	return x
+}
+
+// lnBig returns ln(coef / 10^prec) scaled by 10^s, with an absolute error less than 2 units in the last place.
+// coef must be positive.
+func lnBig(coef *big.Int, prec uint8, s int) *big.Int {
+	w := s + bigGuardDigits
+	one := pow10BigInt(w)
+
+	// coef = m * 2^k with m in [sqrt(2)/2, sqrt(2)]
+	k := coef.BitLen() - 1
+
+	//nolint:gosec // k >= 0, so it's safe to convert to uint
+	m := new(big.Int).Rsh(new(big.Int).Mul(coef, one), uint(k))
+
+	// m > sqrt(2) <=> m^2 > 2
+	m2 := new(big.Int).Mul(m, m)
+	if m2.Cmp(new(big.Int).Lsh(new(big.Int).Mul(one, one), 1)) > 0 {
+		m.Rsh(m, 1)
+		k++
+	}
+
+	// ln(m) = 2*atanh((m-1)/(m+1))
+	z := new(big.Int).Sub(m, one)
+	z.Mul(z, one)
+	z.Quo(z, m.Add(m, one))
+
+	result := atanhBig(z, one)
+	result.Lsh(result, 1)
+
+	ln2 := ln2Big(one)
+	result.Add(result, new(big.Int).Mul(ln2, big.NewInt(int64(k))))
+	result.Sub(result, new(big.Int).Mul(ln10Big(one, ln2), big.NewInt(int64(prec))))
+
+	return result.Quo(result, pow10BigInt(bigGuardDigits))
+}
+
+// ln2Big returns ln(2) = 2*atanh(1/3) scaled by one
+func ln2Big(one *big.Int) *big.Int {
+	r := atanhBig(new(big.Int).Quo(one, big.NewInt(3)), one)
+	return r.Lsh(r, 1)
+}
+
+// ln10Big returns ln(10) = 3*ln(2) + ln(1.25) = 3*ln(2) + 2*atanh(1/9) scaled by one
+func ln10Big(one, ln2 *big.Int) *big.Int {
+	r := atanhBig(new(big.Int).Quo(one, big.NewInt(9)), one)
+	r.Lsh(r, 1)
+	return r.Add(r, new(big.Int).Mul(ln2, big.NewInt(3)))
+}
+
+// atanhBig returns atanh(z) = z + z^3/3 + z^5/5 + ... where z is scaled by one and |z| < 1
+func atanhBig(z, one *big.Int) *big.Int {
+	z2 := new(big.Int).Mul(z, z)
+	z2.Quo(z2, one)
+
+	sum := new(big.Int).Set(z)
+	term := new(big.Int).Set(z)
+	t := new(big.Int)
+
+	for i := int64(3); ; i += 2 {
+		term.Mul(term, z2)
+		term.Quo(term, one)
+		if term.Sign() == 0 {
+			break
+		}
+
+		sum.Add(sum, t.Quo(term, big.NewInt(i)))
+	}
+
+	return sum
+}
+
+// expBig returns e^(x / 10^s) scaled by 10^s, with an error bound given by expErrBig
+func expBig(x *big.Int, s int) *big.Int {
+	w := s + bigGuardDigits
+	one := pow10BigInt(w)
+	ln2 := ln2Big(one)
+
+	// x = k*ln(2) + r with |r| <= ln(2)/2
+	r := new(big.Int).Mul(x, pow10BigInt(bigGuardDigits))
+	half := new(big.Int).Rsh(ln2, 1)
+	if r.Sign() < 0 {
+		half.Neg(half)
+	}
+
+	k := new(big.Int).Add(r, half)
+	k.Quo(k, ln2)
+	r.Sub(r, new(big.Int).Mul(k, ln2))
+
+	// e^r = 1 + r + r^2/2! + r^3/3! + ...
+	sum := new(big.Int).Set(one)
+	term := new(big.Int).Set(one)
+	for i := int64(1); ; i++ {
+		term.Mul(term, r)
+		term.Quo(term, one)
+		term.Quo(term, big.NewInt(i))
+		if term.Sign() == 0 {
+			break
+		}
+
+		sum.Add(sum, term)
+	}
+
+	// k is small because x <= maxExpArg
+	//nolint:gosec // |k| is small, so it's safe to convert to uint
+	if n := k.Int64(); n >= 0 {
+		sum.Lsh(sum, uint(n))
+	} else {
+		sum.Rsh(sum, uint(-n))
+	}
+
+	return sum.Quo(sum, pow10BigInt(bigGuardDigits))
+}
+
+// expErrBig returns the absolute error bound of v = expBig(x, s),
+// which is 2 units in the last place plus a relative error of 10^(-s)
+func expErrBig(v *big.Int, s int) *big.Int {
+	vErr := new(big.Int).Abs(v)
+	vErr.Quo(vErr, pow10BigInt(s))
+	return vErr.Add(vErr, big.NewInt(2))
+}
+
+// logBig returns log(d) with the given base scaled by 10^s and its absolute error bound
+func logBig(d, base Decimal, s int) (*big.Int, *big.Int) {
+	// log(d) = ln(d) / ln(base). When |ln(base)| is small, the errors are amplified after the division.
+	// Use more digits to compensate the leading zeros of ln(base).
+	lb := lnBig(base.coef.GetBig(), base.prec, s)
+
+	w := s + 3
+	if zeros := s - len(new(big.Int).Abs(lb).String()); zeros > 0 {
+		w += zeros
+	}
+
+	lb = lnBig(base.coef.GetBig(), base.prec, w)
+	lx := lnBig(d.coef.GetBig(), d.prec, w)
+
+	q := lx.Mul(lx, pow10BigInt(s))
+	q.Quo(q, lb)
+
+	return q, expErrBig(q, s)
+}
+
+// powExact returns d^e if the result has at most defaultPrec digits after the decimal point, e.g. 4^0.5 = 2.
+// d must be positive and e must not be an integer.
+func (d Decimal) powExact(e Decimal) (Decimal, bool) {
+	// e = m/n with gcd(m, n) = 1
+	m := e.coef.GetBig()
+	n := pow10BigInt(int(e.prec))
+	g := new(big.Int).GCD(nil, nil, m, n)
+	m.Quo(m, g)
+	n.Quo(n, g)
+
+	if n.Cmp(big.NewInt(maxPowExactRoot)) > 0 || m.BitLen() > 16 {
+		return Decimal{}, false
+	}
+
+	mInt, nInt := int(m.Int64()), int(n.Int64())
+	dCoef := d.coef.GetBig()
+
+	// skip if the intermediate values are too large
+	if (dCoef.BitLen()+4*int(d.prec))*mInt+64*nInt > maxPowExactBits {
+		return Decimal{}, false
+	}
+
+	// d^e = c / 10^defaultPrec <=> c^n = d^m * 10^(defaultPrec*n)
+	num := new(big.Int).Exp(dCoef, m, nil)
+	den := pow10BigInt(int(d.prec) * mInt)
+	if e.neg {
+		num, den = den, num
+	}
+
+	num.Mul(num, pow10BigInt(int(defaultPrec)*nInt))
+	x, rem := num.QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 {
+		return Decimal{}, false
+	}
+
+	c := rootBig(x, nInt)
+	if new(big.Int).Exp(c, n, nil).Cmp(x) != 0 {
+		return Decimal{}, false
+	}
+
+	return newDecimal(false, bintFromBigInt(c), defaultPrec), true
+}
+
+// rootBig returns the integer n-th root of x using Newton's method, x must be positive
+func rootBig(x *big.Int, n int) *big.Int {
+	if n == 2 {
+		return new(big.Int).Sqrt(x)
+	}
+
+	nBig := big.NewInt(int64(n))
+	n1 := big.NewInt(int64(n - 1))
+
+	// start from an upper bound of the root, z_{k+1} = ((n-1)*z_k + x/z_k^(n-1)) / n
+	//nolint:gosec // x.BitLen() > 0 and n > 0, so it's safe to convert to uint
+	z := new(big.Int).Lsh(bigOne, uint((x.BitLen()+n-1)/n))
+	for {
+		y := new(big.Int).Exp(z, n1, nil)
+		y.Quo(x, y)
+		y.Add(y, new(big.Int).Mul(z, n1))
+		y.Quo(y, nBig)
+
+		if y.Cmp(z) >= 0 {
+			return z
+		}
+
+		z = y
+	}
+}
+
+// powArgBig returns e*ln(d) scaled by 10^s, with an absolute error less than 2 units in the last place.
+// d must be positive.
+func powArgBig(d, e Decimal, s int) *big.Int {
+	eCoef := e.coef.GetBig()
+
+	// ln(d) has an absolute error less than 2*10^(-w), use more digits to compensate
+	// the amplification of the integer part of e
+	w := s + 1
+	if intDigits := len(eCoef.String()) - int(e.prec); intDigits > 0 {
+		w += intDigits
+	}
+
+	t := lnBig(d.coef.GetBig(), d.prec, w)
+	t.Mul(t, eCoef)
+	t.Quo(t, pow10BigInt(w-s+int(e.prec)))
+	if e.neg {
+		t.Neg(t)
+	}
+
+	return t
+}
+
+// pow10BigInt returns 10^n as a big.Int
+func pow10BigInt(n int) *big.Int {
+	if n < len(pow10Big) {
+		return new(big.Int).Set(pow10Big[n])
+	}
+
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// pow5 returns 5^n, n must be less than or equal 27
+func pow5(n uint8) uint64 {
+	r := uint64(1)
+	for i := uint8(0); i < n; i++ {
+		r *= 5
+	}
+
+	return r
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,410 @@
+package udecimal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExp(t *testing.T) {
+	testcases := []struct {
+		a       string
+		want    string
+		wantErr error
+	}{
+		{"0", "1", nil},
+		{"0.0000000000000000001", "1.0000000000000000001", nil},
+		{"-0.0000000000000000001", "0.9999999999999999999", nil},
+		{"0.5", "1.6487212707001281468", nil},
+		{"-0.5", "0.6065306597126334236", nil},
+		{"1", "2.7182818284590452353", nil},
+		{"-1", "0.3678794411714423215", nil},
+		{"2.5", "12.182493960703473438", nil},
+		{"-2.5", "0.0820849986238987951", nil},
+		{"10", "22026.4657948067165169579", nil},
+		{"-10", "0.0000453999297624848", nil},
+		{"20.123456789", "548916442.8091823234841841246", nil},
+		{"-20.123456789", "0.0000000018217708962", nil},
+		{"43", "4727839468229346561.4744575627442803708", nil},
+		{"-43", "0.0000000000000000002", nil},
+		{"-44", "0", nil},
+		{"-1000000", "0", nil},
+		{"50", "5184705528587072464087.4533229334853848274", nil},
+		{"100.5", "44319559098458954160107061979564816895899481.8706306490748769118", nil},
+		{"200", "722597376812574925817747704218930569735687442852731928403269789123221909361473891661561.9265890625705574684", nil},
+		{"460.0000000000000000001", "", ErrExpOutOfRange},
+		{"123456789012345678901234567890", "", ErrExpOutOfRange},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("exp(%s)", tc.a), func(t *testing.T) {
+			a, err := Parse(tc.a)
+			require.NoError(t, err)
+
+			aStr := a.String()
+
+			b, err := a.Exp()
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, b.String())
+
+			// make sure a is immutable
+			require.Equal(t, aStr, a.String())
+		})
+	}
+}
+
+func TestLn(t *testing.T) {
+	testcases := []struct {
+		a       string
+		want    string
+		wantErr error
+	}{
+		{"0", "", ErrLogNonPositive},
+		{"-1", "", ErrLogNonPositive},
+		{"1", "0", nil},
+		{"0.0000000000000000001", "-43.7491167668868679963", nil},
+		{"0.001", "-6.907755278982137052", nil},
+		{"0.5", "-0.6931471805599453094", nil},
+		{"0.9999999999999999999", "-0.0000000000000000001", nil},
+		{"1.0000000000000000001", "0", nil},
+		{"1.5", "0.4054651081081643819", nil},
+		{"2", "0.6931471805599453094", nil},
+		{"2.7182818284590452354", "1", nil},
+		{"3", "1.0986122886681096913", nil},
+		{"10", "2.302585092994045684", nil},
+		{"123.456", "4.8158848172832638831", nil},
+		{"1000000", "13.8155105579642741041", nil},
+		{"12345678901234567890.1234567890123456789", "43.9598377892025205573", nil},
+		{"123456789012345678901234567890123456789", "87.7089545560893885537", nil},
+		{"1234567890123456789012345678901234567890123456789", "110.7348054860298453939", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("ln(%s)", tc.a), func(t *testing.T) {
+			a, err := Parse(tc.a)
+			require.NoError(t, err)
+
+			aStr := a.String()
+
+			b, err := a.Ln()
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, b.String())
+
+			// make sure a is immutable
+			require.Equal(t, aStr, a.String())
+		})
+	}
+}
+
+func TestLog10(t *testing.T) {
+	testcases := []struct {
+		a       string
+		want    string
+		wantErr error
+	}{
+		{"0", "", ErrLogNonPositive},
+		{"-10", "", ErrLogNonPositive},
+		{"1", "0", nil},
+		{"10", "1", nil},
+		{"1000000", "6", nil},
+		{"100000000000000000000000000000000000000", "38", nil},
+		{"1000000000000000000000000000000000000000000000000", "48", nil},
+		{"0.1", "-1", nil},
+		{"0.001", "-3", nil},
+		{"0.0000000000000000001", "-19", nil},
+		{"0.5", "-0.3010299956639811952", nil},
+		{"0.9999999999999999999", "0", nil},
+		{"1.5", "0.176091259055681242", nil},
+		{"2", "0.3010299956639811952", nil},
+		{"3", "0.4771212547196624372", nil},
+		{"123.456", "2.091512201627771681", nil},
+		{"12345678901234567890.1234567890123456789", "19.0915149772126998957", nil},
+		{"123456789012345678901234567890123456789", "38.0915149772126998957", nil},
+		{"1234567890123456789012345678901234567890123456789", "48.0915149772126998957", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("log10(%s)", tc.a), func(t *testing.T) {
+			a, err := Parse(tc.a)
+			require.NoError(t, err)
+
+			b, err := a.Log10()
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, b.String())
+		})
+	}
+}
+
+func TestLog2(t *testing.T) {
+	testcases := []struct {
+		a       string
+		want    string
+		wantErr error
+	}{
+		{"0", "", ErrLogNonPositive},
+		{"-2", "", ErrLogNonPositive},
+		{"1", "0", nil},
+		{"2", "1", nil},
+		{"8.000", "3", nil},
+		{"1024", "10", nil},
+		{"170141183460469231731687303715884105728", "127", nil},
+		{"340282366920938463463374607431768211456", "128", nil},
+		{"0.5", "-1", nil},
+		{"0.125", "-3", nil},
+		{"0.0000000000000000001", "-63.1166338028598846095", nil},
+		{"1.0000000000000000001", "0.0000000000000000001", nil},
+		{"1.5", "0.5849625007211561814", nil},
+		{"2.7182818284590452354", "1.4426950408889634073", nil},
+		{"3", "1.5849625007211561814", nil},
+		{"10", "3.3219280948873623478", nil},
+		{"123.456", "6.9478531433870164557", nil},
+		{"123456789012345678901234567890123456789", "126.5372737796256137612", nil},
+		{"1234567890123456789012345678901234567890123456789", "159.7565547284992372399", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("log2(%s)", tc.a), func(t *testing.T) {
+			a, err := Parse(tc.a)
+			require.NoError(t, err)
+
+			b, err := a.Log2()
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, b.String())
+		})
+	}
+}
+
+func TestLog(t *testing.T) {
+	testcases := []struct {
+		a, base string
+		want    string
+		wantErr error
+	}{
+		{"0", "2", "", ErrLogNonPositive},
+		{"-1", "2", "", ErrLogNonPositive},
+		{"2", "0", "", ErrLogInvalidBase},
+		{"2", "-2", "", ErrLogInvalidBase},
+		{"2", "1", "", ErrLogInvalidBase},
+		{"1", "3", "0", nil},
+		{"1000", "10", "3", nil},
+		{"1024", "2", "10", nil},
+		{"100", "3", "4.1918065485787692085", nil},
+		{"2", "4", "0.5", nil},
+		{"8", "4", "1.5", nil},
+		{"0.001", "0.1", "3", nil},
+		{"5", "0.2", "-1", nil},
+		{"1000000", "1.000001", "13815517.4657184017942741496", nil},
+		{"12345.6789", "7.5", "4.6756897469220992753", nil},
+		{"1.05", "1.0000000000000000001", "487901641694320030.6781391243163625876", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("log(%s, %s)", tc.a, tc.base), func(t *testing.T) {
+			a, err := Parse(tc.a)
+			require.NoError(t, err)
+
+			base, err := Parse(tc.base)
+			require.NoError(t, err)
+
+			b, err := a.Log(base)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, b.String())
+		})
+	}
+}
+
+func TestPow(t *testing.T) {
+	testcases := []struct {
+		a, e    string
+		want    string
+		wantErr error
+	}{
+		{"0", "0", "1", nil},
+		{"0", "0.5", "0", nil},
+		{"0", "-0.5", "", ErrZeroPowNegative},
+		{"0", "-2", "", ErrZeroPowNegative},
+		{"-2", "0.5", "", ErrPowNegativeBase},
+		{"-2", "3", "-8", nil},
+		{"-2", "3.000", "-8", nil},
+		{"2", "3", "8", nil},
+		{"2", "-2", "0.25", nil},
+		{"1", "123.456", "1", nil},
+		{"1.23", "2.5", "1.6778872680546807224", nil},
+		{"1.05", "0.0821917808219178082", "1.004018201891974921", nil},
+		{"2", "0.5", "1.4142135623730950488", nil},
+		{"2", "-0.5", "0.7071067811865475244", nil},
+		{"4", "0.5", "2", nil},
+		{"0.25", "0.5", "0.5", nil},
+		{"100", "1.5", "1000", nil},
+		{"16", "-0.25", "0.5", nil},
+		{"1.21", "0.5", "1.1", nil},
+		{"1024", "0.1", "2", nil},
+		{"10", "-19.5", "0", nil},
+		{"0.5", "0.0000000000000000001", "0.9999999999999999999", nil},
+		{"1.0000000000000000001", "1000000000000.5", "1.000000100000005", nil},
+		{"123456789.123456789", "2.5", "169350874654947602883.8337838637719398096", nil},
+		{"123456789012345678901234567890123456789", "0.5", "11111111061111110993.6111105818611081081", nil},
+		{"3", "123.456", "80072174495721166302260341334348847948168011302880820278205.2322923020448778288", nil},
+		{"0.001", "-66.6", "", ErrExpOutOfRange},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s^%s", tc.a, tc.e), func(t *testing.T) {
+			a, err := Parse(tc.a)
+			require.NoError(t, err)
+
+			e, err := Parse(tc.e)
+			require.NoError(t, err)
+
+			b, err := a.Pow(e)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, b.String())
+		})
+	}
+}
+
+func TestPowRound(t *testing.T) {
+	testcases := []struct {
+		a, e    string
+		prec    uint8
+		mode    RoundingMode
+		want    string
+		wantErr error
+	}{
+		{"0", "-0.5", 2, RoundHalfEven, "", ErrZeroPowNegative},
+		{"-2", "0.5", 2, RoundHalfEven, "", ErrPowNegativeBase},
+		{"0.001", "-66.6", 2, RoundHalfEven, "", ErrExpOutOfRange},
+		{"2", "2147483648", 2, RoundHalfEven, "", ErrExponentTooLarge},
+		{"0", "0.5", 2, RoundUp, "0", nil},
+		{"1", "123.456", 2, RoundUp, "1", nil},
+		{"2", "0.5", 4, RoundHalfEven, "1.4142", nil},
+		{"2", "0.5", 4, RoundCeiling, "1.4143", nil},
+		{"2", "0.5", 19, RoundHalfEven, "1.4142135623730950488", nil},
+		{"2", "0.5", 19, RoundCeiling, "1.4142135623730950489", nil},
+		{"1.000001", "1000000.5", 4, RoundHalfEven, "2.7183", nil},
+		{"123456789012345678901234567890", "0.5", 2, RoundHalfUp, "351364182882014.43", nil},
+
+		// exact results on half-way points
+		{"1.5625", "0.5", 1, RoundHalfEven, "1.2", nil},
+		{"1.5625", "0.5", 1, RoundHalfUp, "1.3", nil},
+		{"1.5625", "0.5", 1, RoundHalfDown, "1.2", nil},
+		{"1.5625", "0.5", 1, RoundHalfOdd, "1.3", nil},
+		{"2.25", "1.5", 2, RoundHalfEven, "3.38", nil},
+		{"2.25", "1.5", 2, RoundHalfDown, "3.37", nil},
+		{"1.5", "3", 2, RoundHalfEven, "3.38", nil},
+		{"1.5", "3", 2, RoundHalfDown, "3.37", nil},
+		{"-1.5", "3", 2, RoundHalfEven, "-3.38", nil},
+		{"-1.5", "3", 2, RoundCeiling, "-3.37", nil},
+		{"-1.5", "3", 2, RoundFloor, "-3.38", nil},
+
+		// exact results with prec digits, only rounded up when the discarded part isn't zero
+		{"6.25", "-1.5", 2, RoundHalfEven, "0.06", nil},
+		{"6.25", "-1.5", 2, RoundUp, "0.07", nil},
+		{"6.25", "-1.5", 3, RoundUp, "0.064", nil},
+		{"0.0001", "0.25", 1, RoundUp, "0.1", nil},
+		{"1208925819614629174706176", "0.0125", 0, RoundUp, "2", nil},
+		{"1208925819614629174706176", "0.0125", 0, RoundDown, "2", nil},
+		{"1.05", "-3", 2, RoundHalfEven, "0.86", nil},
+		{"1.05", "-3", 2, RoundCeiling, "0.87", nil},
+
+		// results less than half a unit
+		{"0.1", "60.5", 19, RoundHalfEven, "0", nil},
+		{"0.1", "60.5", 19, RoundUp, "0.0000000000000000001", nil},
+		{"0.1", "60.5", 10, Round05Up, "0.0000000001", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s^%s_%d_%s", tc.a, tc.e, tc.prec, tc.mode), func(t *testing.T) {
+			q, err := MustParse(tc.a).PowRound(MustParse(tc.e), tc.prec, tc.mode)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, q.String())
+		})
+	}
+
+	require.Panics(t, func() {
+		_, _ = two.PowRound(MustParse("0.5"), 2, RoundingMode(20))
+	})
+}
+
+func TestPowRoundConsistency(t *testing.T) {
+	// with RoundDown, PowRound truncates like Pow
+	inputs := []string{"0.5", "1.05", "2", "3.1415926535", "123456789.987654321"}
+	exps := []string{"-2.5", "-1", "0.0821917808219178082", "0.5", "3", "7.25"}
+
+	for _, a := range inputs {
+		for _, e := range exps {
+			want, err := MustParse(a).Pow(MustParse(e))
+			require.NoError(t, err)
+
+			got, err := MustParse(a).PowRound(MustParse(e), maxPrec, RoundDown)
+			require.NoError(t, err)
+			require.Equal(t, want.String(), got.String(), "%s^%s", a, e)
+		}
+	}
+}
+
+func TestExpLnWithCustomPrecision(t *testing.T) {
+	SetDefaultPrecision(10)
+	defer SetDefaultPrecision(maxPrec)
+
+	testcases := []struct {
+		a       string
+		wantExp string
+		wantLn  string
+	}{
+		{"0.5", "1.6487212707", "-0.6931471805"},
+		{"2", "7.3890560989", "0.6931471805"},
+		{"3", "20.0855369231", "1.0986122886"},
+		{"10", "22026.4657948067", "2.3025850929"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.a, func(t *testing.T) {
+			a := MustParse(tc.a)
+
+			e, err := a.Exp()
+			require.NoError(t, err)
+			require.Equal(t, tc.wantExp, e.String())
+
+			l, err := a.Ln()
+			require.NoError(t, err)
+			require.Equal(t, tc.wantLn, l.String())
+		})
+	}
+}
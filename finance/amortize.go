@@ -0,0 +1,194 @@
+package finance
+
+import (
+	"fmt"
+
+	"github.com/markovichecha/udecimal"
+)
+
+var (
+	// ErrInvalidPrincipal is returned when the principal of a loan is not positive
+	ErrInvalidPrincipal = fmt.Errorf("finance: principal must be positive")
+
+	// ErrInvalidPeriods is returned when the number of periods is not positive
+	// or the interest-only periods are negative or more than the number of periods
+	ErrInvalidPeriods = fmt.Errorf("finance: invalid number of periods")
+
+	// ErrInvalidFrequency is returned when the payment frequency is zero
+	ErrInvalidFrequency = fmt.Errorf("finance: payment frequency must be positive")
+
+	// ErrInvalidBalloon is returned when the balloon payment is negative or greater than the principal
+	ErrInvalidBalloon = fmt.Errorf("finance: balloon payment must be between 0 and the principal")
+)
+
+// Frequency is the number of payments per year.
+type Frequency uint16
+
+const (
+	// Annually is 1 payment per year.
+	Annually Frequency = 1
+
+	// SemiAnnually is 2 payments per year.
+	SemiAnnually Frequency = 2
+
+	// Quarterly is 4 payments per year.
+	Quarterly Frequency = 4
+
+	// Monthly is 12 payments per year.
+	Monthly Frequency = 12
+
+	// Biweekly is 26 payments per year, one every two weeks.
+	Biweekly Frequency = 26
+
+	// Weekly is 52 payments per year.
+	Weekly Frequency = 52
+)
+
+// AmortizeOptions are the options of [Amortize].
+// Use [DefaultAmortizeOptions] to get the defaults and override the fields as needed.
+type AmortizeOptions struct {
+	// Frequency is the number of payments per year. The rate per period is annualRate / Frequency.
+	Frequency Frequency
+
+	// InterestOnlyPeriods is the number of periods at the start of the loan where only the interest is paid.
+	InterestOnlyPeriods int
+
+	// Balloon is the part of the principal that is left to be paid with the last payment.
+	// Like the principal, it's rounded to Prec digits.
+	Balloon udecimal.Decimal
+
+	// Prec is the number of digits after the decimal point of every amount,
+	// usually the minor unit of the currency, e.g. 2 for USD and 0 for JPY.
+	Prec uint8
+
+	// Rounding is the rounding mode used to round every amount to Prec digits.
+	Rounding udecimal.RoundingMode
+}
+
+// DefaultAmortizeOptions returns the default options: monthly payments, no interest-only periods,
+// no balloon payment and amounts rounded half to even to 2 digits after the decimal point.
+func DefaultAmortizeOptions() AmortizeOptions {
+	return AmortizeOptions{
+		Frequency: Monthly,
+		Balloon:   udecimal.Zero,
+		Prec:      2,
+		Rounding:  udecimal.RoundHalfEven,
+	}
+}
+
+// AmortizationRow is one period of an amortization schedule.
+type AmortizationRow struct {
+	// Period is the number of the period, starting from 1.
+	Period int
+
+	// Payment is the amount paid at the end of the period, Payment = Interest + Principal.
+	Payment udecimal.Decimal
+
+	// Interest is the interest of the period.
+	Interest udecimal.Decimal
+
+	// Principal is the part of the payment that repays the principal.
+	Principal udecimal.Decimal
+
+	// Balance is the principal left after the payment.
+	Balance udecimal.Decimal
+}
+
+// Amortize returns the amortization schedule of a loan with payments at the end of each period.
+//
+// The loan is repaid with level payments after the interest-only periods (see [AmortizeOptions]).
+// The payment and the interest of every period are rounded to opts.Prec digits,
+// and the last payment is adjusted so the balance ends at exactly zero. It includes the balloon payment, if any.
+// If the rounded payment repays the loan before the last period, the schedule ends early.
+//
+// Returns error if:
+//   - principal is not positive ([ErrInvalidPrincipal])
+//   - periods is not positive, or opts.InterestOnlyPeriods is negative or greater than periods ([ErrInvalidPeriods])
+//   - opts.Frequency is zero ([ErrInvalidFrequency])
+//   - opts.Balloon is negative or greater than principal after rounding both to opts.Prec digits ([ErrInvalidBalloon])
+//   - the rate per period is less than or equal to -1 ([ErrInvalidRate])
+//
+// Panics if opts.Rounding is not a valid [udecimal.RoundingMode].
+//
+// Example:
+//
+//	Amortize(1000, 0.12, 3, DefaultAmortizeOptions()) =
+//	  1: payment 340.02, interest 10.00, principal 330.02, balance 669.98
+//	  2: payment 340.02, interest  6.70, principal 333.32, balance 336.66
+//	  3: payment 340.03, interest  3.37, principal 336.66, balance   0.00
+func Amortize(principal, annualRate udecimal.Decimal, periods int, opts AmortizeOptions) ([]AmortizationRow, error) {
+	if !principal.IsPos() {
+		return nil, ErrInvalidPrincipal
+	}
+
+	if periods <= 0 || opts.InterestOnlyPeriods < 0 || opts.InterestOnlyPeriods > periods {
+		return nil, ErrInvalidPeriods
+	}
+
+	if opts.Frequency == 0 {
+		return nil, ErrInvalidFrequency
+	}
+
+	// the balloon is repaid with the last payment, so it's rounded like the principal
+	balance := principal.Round(opts.Prec, opts.Rounding)
+	balloon := opts.Balloon.Round(opts.Prec, opts.Rounding)
+	if balloon.IsNeg() || balloon.GreaterThan(balance) {
+		return nil, ErrInvalidBalloon
+	}
+
+	rate, err := annualRate.Div(udecimal.MustFromUint64(uint64(opts.Frequency), 0))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkArgs(rate, AtEnd); err != nil {
+		return nil, err
+	}
+
+	// level payment of the amortizing periods, from the principal down to the balloon
+	payment := udecimal.Zero
+	if n := periods - opts.InterestOnlyPeriods; n > 0 {
+		pmt, err := PMT(rate, udecimal.MustFromInt64(int64(n), 0), balance, balloon.Neg(), AtEnd)
+		if err != nil {
+			return nil, err
+		}
+
+		payment = pmt.Neg().Round(opts.Prec, opts.Rounding)
+	}
+
+	rows := make([]AmortizationRow, 0, periods)
+	for period := 1; period <= periods; period++ {
+		interest := balance.Mul(rate).Round(opts.Prec, opts.Rounding)
+
+		var principalPart udecimal.Decimal
+		switch {
+		case period == periods:
+			// repay everything that is left, including the balloon
+			principalPart = balance
+		case period <= opts.InterestOnlyPeriods:
+			principalPart = udecimal.Zero
+		default:
+			principalPart = payment.Sub(interest)
+		}
+
+		// the rounded payment repays the loan early
+		if principalPart.GreaterThan(balance) {
+			principalPart = balance
+		}
+
+		balance = balance.Sub(principalPart)
+		rows = append(rows, AmortizationRow{
+			Period:    period,
+			Payment:   interest.Add(principalPart),
+			Interest:  interest,
+			Principal: principalPart,
+			Balance:   balance,
+		})
+
+		if balance.IsZero() {
+			break
+		}
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,217 @@
+package finance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markovichecha/udecimal"
+)
+
+// scheduleString formats each row as "period payment interest principal balance"
+func scheduleString(rows []AmortizationRow) []string {
+	s := make([]string, len(rows))
+	for i, r := range rows {
+		s[i] = fmt.Sprintf("%d %s %s %s %s", r.Period, r.Payment, r.Interest, r.Principal, r.Balance)
+	}
+
+	return s
+}
+
+func TestAmortize(t *testing.T) {
+	m := udecimal.MustParse
+
+	withOpts := func(fn func(*AmortizeOptions)) AmortizeOptions {
+		opts := DefaultAmortizeOptions()
+		fn(&opts)
+		return opts
+	}
+
+	testcases := []struct {
+		name      string
+		principal string
+		rate      string
+		periods   int
+		opts      AmortizeOptions
+		want      []string
+	}{
+		{
+			"monthly", "1000", "0.12", 3, DefaultAmortizeOptions(),
+			[]string{
+				"1 340.02 10 330.02 669.98",
+				"2 340.02 6.7 333.32 336.66",
+				"3 340.03 3.37 336.66 0",
+			},
+		},
+		{
+			"zero rate", "100", "0", 3, DefaultAmortizeOptions(),
+			[]string{
+				"1 33.33 0 33.33 66.67",
+				"2 33.33 0 33.33 33.34",
+				"3 33.34 0 33.34 0",
+			},
+		},
+		{
+			"annual no minor unit", "100000", "0.05", 3,
+			withOpts(func(o *AmortizeOptions) { o.Frequency = Annually; o.Prec = 0 }),
+			[]string{
+				"1 36721 5000 31721 68279",
+				"2 36721 3414 33307 34972",
+				"3 36721 1749 34972 0",
+			},
+		},
+		{
+			"round up", "1000", "0.12", 3,
+			withOpts(func(o *AmortizeOptions) { o.Rounding = udecimal.RoundUp }),
+			[]string{
+				"1 340.03 10 330.03 669.97",
+				"2 340.03 6.7 333.33 336.64",
+				"3 340.01 3.37 336.64 0",
+			},
+		},
+		{
+			"interest only and balloon", "10000", "0.06", 6,
+			withOpts(func(o *AmortizeOptions) { o.InterestOnlyPeriods = 2; o.Balloon = m("500") }),
+			[]string{
+				"1 50 50 0 10000",
+				"2 50 50 0 10000",
+				"3 2407.26 50 2357.26 7642.74",
+				"4 2407.26 38.21 2369.05 5273.69",
+				"5 2407.26 26.37 2380.89 2892.8",
+				"6 2907.26 14.46 2892.8 0",
+			},
+		},
+		{
+			"bullet", "1000", "0.12", 3,
+			withOpts(func(o *AmortizeOptions) { o.InterestOnlyPeriods = 3 }),
+			[]string{
+				"1 10 10 0 1000",
+				"2 10 10 0 1000",
+				"3 1010 10 1000 0",
+			},
+		},
+		{
+			"balloon is the whole principal", "1000", "0.12", 2,
+			withOpts(func(o *AmortizeOptions) { o.Balloon = m("1000") }),
+			[]string{
+				"1 10 10 0 1000",
+				"2 1010 10 1000 0",
+			},
+		},
+		{
+			"balloon is rounded to the minor unit", "1000", "0.12", 2,
+			withOpts(func(o *AmortizeOptions) { o.Balloon = m("2.005") }),
+			[]string{
+				"1 506.52 10 496.52 503.48",
+				"2 508.51 5.03 503.48 0",
+			},
+		},
+		{
+			"ends early", "0.05", "0", 10,
+			withOpts(func(o *AmortizeOptions) { o.Rounding = udecimal.RoundUp }),
+			[]string{
+				"1 0.01 0 0.01 0.04",
+				"2 0.01 0 0.01 0.03",
+				"3 0.01 0 0.01 0.02",
+				"4 0.01 0 0.01 0.01",
+				"5 0.01 0 0.01 0",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows, err := Amortize(m(tc.principal), m(tc.rate), tc.periods, tc.opts)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, scheduleString(rows))
+		})
+	}
+}
+
+func TestAmortizeInvariants(t *testing.T) {
+	m := udecimal.MustParse
+
+	testcases := []struct {
+		principal string
+		rate      string
+		periods   int
+		frequency Frequency
+		prec      uint8
+	}{
+		{"200000", "0.065", 360, Monthly, 2},
+		{"123456.78", "0.0399", 180, Monthly, 2},
+		{"5000", "0.18", 52, Weekly, 2},
+		{"25000", "0.07", 130, Biweekly, 2},
+		{"3000000", "0.021", 40, Quarterly, 0},
+		{"999.999", "0.1", 12, SemiAnnually, 3},
+		{"10000", "-0.01", 24, Monthly, 2},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s/%s/%d", tc.principal, tc.rate, tc.periods), func(t *testing.T) {
+			opts := DefaultAmortizeOptions()
+			opts.Frequency = tc.frequency
+			opts.Prec = tc.prec
+
+			rows, err := Amortize(m(tc.principal), m(tc.rate), tc.periods, opts)
+			require.NoError(t, err)
+			require.Len(t, rows, tc.periods)
+
+			total := udecimal.Zero
+			for i, r := range rows {
+				require.Equal(t, i+1, r.Period)
+				require.Equal(t, r.Payment, r.Interest.Add(r.Principal))
+				require.LessOrEqual(t, r.Payment.PrecUint(), tc.prec)
+				require.LessOrEqual(t, r.Interest.PrecUint(), tc.prec)
+
+				// level payments, only the last one is adjusted
+				if i > 0 && i < len(rows)-1 {
+					require.Equal(t, rows[0].Payment, r.Payment)
+				}
+
+				total = total.Add(r.Principal)
+			}
+
+			require.True(t, rows[len(rows)-1].Balance.IsZero())
+			require.Equal(t, m(tc.principal).String(), total.String())
+		})
+	}
+}
+
+func TestAmortizeErrors(t *testing.T) {
+	m := udecimal.MustParse
+
+	withOpts := func(fn func(*AmortizeOptions)) AmortizeOptions {
+		opts := DefaultAmortizeOptions()
+		fn(&opts)
+		return opts
+	}
+
+	testcases := []struct {
+		name      string
+		principal string
+		rate      string
+		periods   int
+		opts      AmortizeOptions
+		wantErr   error
+	}{
+		{"zero principal", "0", "0.1", 12, DefaultAmortizeOptions(), ErrInvalidPrincipal},
+		{"negative principal", "-1000", "0.1", 12, DefaultAmortizeOptions(), ErrInvalidPrincipal},
+		{"zero periods", "1000", "0.1", 0, DefaultAmortizeOptions(), ErrInvalidPeriods},
+		{"negative interest-only periods", "1000", "0.1", 12, withOpts(func(o *AmortizeOptions) { o.InterestOnlyPeriods = -1 }), ErrInvalidPeriods},
+		{"too many interest-only periods", "1000", "0.1", 12, withOpts(func(o *AmortizeOptions) { o.InterestOnlyPeriods = 13 }), ErrInvalidPeriods},
+		{"zero frequency", "1000", "0.1", 12, AmortizeOptions{}, ErrInvalidFrequency},
+		{"negative balloon", "1000", "0.1", 12, withOpts(func(o *AmortizeOptions) { o.Balloon = m("-1") }), ErrInvalidBalloon},
+		{"balloon greater than principal", "1000", "0.1", 12, withOpts(func(o *AmortizeOptions) { o.Balloon = m("1000.01") }), ErrInvalidBalloon},
+		{"rounded balloon greater than principal", "1000", "0.1", 12, withOpts(func(o *AmortizeOptions) { o.Balloon = m("1000.005"); o.Rounding = udecimal.RoundUp }), ErrInvalidBalloon},
+		{"rate", "1000", "-12", 12, DefaultAmortizeOptions(), ErrInvalidRate},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Amortize(m(tc.principal), m(tc.rate), tc.periods, tc.opts)
+			require.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
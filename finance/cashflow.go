@@ -0,0 +1,232 @@
+package finance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/markovichecha/udecimal"
+)
+
+var (
+	// ErrInvalidCashFlows is returned when the cash flows don't contain at least one positive and one negative value
+	ErrInvalidCashFlows = fmt.Errorf("finance: cash flows must contain at least one positive and one negative value")
+
+	// ErrLengthMismatch is returned when the values and the dates have different lengths
+	ErrLengthMismatch = fmt.Errorf("finance: values and dates have different lengths")
+
+	// ErrInvalidDates is returned when a date is before the first date
+	ErrInvalidDates = fmt.Errorf("finance: dates must not be before the first date")
+)
+
+// daysPerYear is the day count basis of XNPV and XIRR
+const daysPerYear = 365
+
+var days = udecimal.MustFromUint64(daysPerYear, 0)
+
+// NPV returns the net present value of values, which are paid at the end of periods 1, 2, ...
+//
+//	NPV = sum(values[i] / (1+rate)^(i+1))
+//
+// Example:
+//
+//	NPV(0.1, [-10000, 3000, 4200, 6800]) = 1188.4434123352...
+func NPV(rate udecimal.Decimal, values []udecimal.Decimal) (udecimal.Decimal, error) {
+	if rate.LessThanOrEqual(one.Neg()) {
+		return udecimal.Decimal{}, ErrInvalidRate
+	}
+
+	npv, _, err := npvFunc(rate, values, 1)
+	return npv, err
+}
+
+// IRR returns the internal rate of return of values, which are paid at the start of periods 0, 1, 2, ...,
+// i.e. the rate at which their net present value is zero:
+//
+//	sum(values[i] / (1+rate)^i) = 0
+//
+// The rate is solved with Newton's method starting from guess (0.1 is a good default).
+//
+// Returns error if:
+//   - values doesn't contain at least one positive and one negative value ([ErrInvalidCashFlows])
+//   - the solver doesn't converge ([ErrNoConvergence])
+//
+// Example:
+//
+//	IRR([-70000, 12000, 15000, 18000, 21000, 26000], 0.1) = 0.0866309480365...
+func IRR(values []udecimal.Decimal, guess udecimal.Decimal) (udecimal.Decimal, error) {
+	if !hasSignChange(values) {
+		return udecimal.Decimal{}, ErrInvalidCashFlows
+	}
+
+	return solve(guess, func(r udecimal.Decimal) (udecimal.Decimal, udecimal.Decimal, error) {
+		return npvFunc(r, values, 0)
+	})
+}
+
+// XNPV returns the net present value of values paid at the given dates, discounted to the first date:
+//
+//	XNPV = sum(values[i] / (1+rate)^((dates[i]-dates[0])/365))
+//
+// Only the dates are used, the time of day is ignored.
+//
+// Returns error if:
+//   - values and dates have different lengths ([ErrLengthMismatch])
+//   - a date is before the first date ([ErrInvalidDates])
+//
+// Example:
+//
+//	XNPV(0.09, [-10000, 2750, 4250, 3250, 2750], [2008-01-01, 2008-03-01, 2008-10-30, 2009-02-15, 2009-04-01]) = 2086.6476020315...
+func XNPV(rate udecimal.Decimal, values []udecimal.Decimal, dates []time.Time) (udecimal.Decimal, error) {
+	if rate.LessThanOrEqual(one.Neg()) {
+		return udecimal.Decimal{}, ErrInvalidRate
+	}
+
+	years, err := yearFractions(values, dates)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	xnpv, _, err := xnpvFunc(rate, values, years)
+	return xnpv, err
+}
+
+// XIRR returns the internal rate of return of values paid at the given dates,
+// i.e. the rate at which their XNPV is zero.
+//
+// The rate is solved with Newton's method starting from guess (0.1 is a good default).
+//
+// Returns error if:
+//   - values and dates have different lengths ([ErrLengthMismatch])
+//   - a date is before the first date ([ErrInvalidDates])
+//   - values doesn't contain at least one positive and one negative value ([ErrInvalidCashFlows])
+//   - the solver doesn't converge ([ErrNoConvergence])
+//
+// Example:
+//
+//	XIRR([-10000, 2750, 4250, 3250, 2750], [2008-01-01, 2008-03-01, 2008-10-30, 2009-02-15, 2009-04-01], 0.1) = 0.3733625335...
+func XIRR(values []udecimal.Decimal, dates []time.Time, guess udecimal.Decimal) (udecimal.Decimal, error) {
+	years, err := yearFractions(values, dates)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	if !hasSignChange(values) {
+		return udecimal.Decimal{}, ErrInvalidCashFlows
+	}
+
+	return solve(guess, func(r udecimal.Decimal) (udecimal.Decimal, udecimal.Decimal, error) {
+		return xnpvFunc(r, values, years)
+	})
+}
+
+// npvFunc returns the net present value of values, where values[i] is paid at period i+first,
+// and its derivative with respect to rate
+func npvFunc(rate udecimal.Decimal, values []udecimal.Decimal, first int) (udecimal.Decimal, udecimal.Decimal, error) {
+	base := one.Add(rate)
+	if !base.IsPos() {
+		return udecimal.Decimal{}, udecimal.Decimal{}, ErrInvalidRate
+	}
+
+	// discount = 1/(1+rate), v = discount^t
+	discount, err := one.Div(base)
+	if err != nil {
+		return udecimal.Decimal{}, udecimal.Decimal{}, err
+	}
+
+	v, err := discount.PowInt32(int32(first)) //nolint:gosec // first is 0 or 1
+	if err != nil {
+		return udecimal.Decimal{}, udecimal.Decimal{}, err
+	}
+
+	// d/drate values[i]*v^t = -t*values[i]*v^(t+1)
+	npv, deriv := udecimal.Zero, udecimal.Zero
+	for i, x := range values {
+		t := udecimal.MustFromInt64(int64(i+first), 0)
+		term := x.Mul(v)
+
+		npv = npv.Add(term)
+		deriv = deriv.Sub(t.Mul(term).Mul(discount))
+		v = v.Mul(discount)
+	}
+
+	return npv, deriv, nil
+}
+
+// xnpvFunc returns the XNPV of values paid after the given year fractions and its derivative with respect to rate
+func xnpvFunc(rate udecimal.Decimal, values, years []udecimal.Decimal) (udecimal.Decimal, udecimal.Decimal, error) {
+	base := one.Add(rate)
+	if !base.IsPos() {
+		return udecimal.Decimal{}, udecimal.Decimal{}, ErrInvalidRate
+	}
+
+	npv, deriv := udecimal.Zero, udecimal.Zero
+	for i, x := range values {
+		// (1+rate)^t
+		f, err := pow1p(rate, years[i])
+		if err != nil {
+			return udecimal.Decimal{}, udecimal.Decimal{}, err
+		}
+
+		term, err := x.Div(f)
+		if err != nil {
+			return udecimal.Decimal{}, udecimal.Decimal{}, err
+		}
+
+		// d/drate x/(1+rate)^t = -t*x/(1+rate)^(t+1)
+		d, err := years[i].Mul(term).Div(base)
+		if err != nil {
+			return udecimal.Decimal{}, udecimal.Decimal{}, err
+		}
+
+		npv = npv.Add(term)
+		deriv = deriv.Sub(d)
+	}
+
+	return npv, deriv, nil
+}
+
+// yearFractions returns (dates[i]-dates[0])/365 in years
+func yearFractions(values []udecimal.Decimal, dates []time.Time) ([]udecimal.Decimal, error) {
+	if len(values) != len(dates) {
+		return nil, ErrLengthMismatch
+	}
+
+	if len(dates) == 0 {
+		return nil, nil
+	}
+
+	start := civilDays(dates[0])
+
+	years := make([]udecimal.Decimal, len(dates))
+	for i, d := range dates {
+		n := civilDays(d) - start
+		if n < 0 {
+			return nil, ErrInvalidDates
+		}
+
+		y, err := udecimal.MustFromInt64(n, 0).Div(days)
+		if err != nil {
+			return nil, err
+		}
+
+		years[i] = y
+	}
+
+	return years, nil
+}
+
+// civilDays returns the number of days since 1970-01-01 of the date of t, ignoring the time of day and the time zone
+func civilDays(t time.Time) int64 {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+func hasSignChange(values []udecimal.Decimal) bool {
+	var pos, neg bool
+	for _, v := range values {
+		pos = pos || v.IsPos()
+		neg = neg || v.IsNeg()
+	}
+
+	return pos && neg
+}
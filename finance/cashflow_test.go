@@ -0,0 +1,133 @@
+package finance
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markovichecha/udecimal"
+)
+
+func parseAll(ss ...string) []udecimal.Decimal {
+	ds := make([]udecimal.Decimal, len(ss))
+	for i, s := range ss {
+		ds[i] = udecimal.MustParse(s)
+	}
+
+	return ds
+}
+
+func parseDates(ss ...string) []time.Time {
+	ds := make([]time.Time, len(ss))
+	for i, s := range ss {
+		d, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			panic(err)
+		}
+
+		ds[i] = d
+	}
+
+	return ds
+}
+
+func TestNPVIRR(t *testing.T) {
+	testcases := []struct {
+		values []string
+		rate   string
+		npv    string
+		irr    string
+	}{
+		{[]string{"-10000", "3000", "4200", "6800"}, "0.1", "1188.4434123352", "0.1634056007"},
+		{[]string{"-70000", "12000", "15000", "18000", "21000", "26000"}, "0.1", "-2439.3740887274", "0.086630948"},
+		{[]string{"-70000", "12000", "15000", "18000", "21000"}, "0.1", "-17115.6962701256", "-0.0212448483"},
+		{[]string{"-100", "110"}, "0", "10", "0.1"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprint(tc.values), func(t *testing.T) {
+			values := parseAll(tc.values...)
+
+			npv, err := NPV(udecimal.MustParse(tc.rate), values)
+			require.NoError(t, err)
+			requireDecimal(t, tc.npv, npv)
+
+			irr, err := IRR(values, udecimal.MustParse("0.1"))
+			require.NoError(t, err)
+			requireDecimal(t, tc.irr, irr)
+
+			// the NPV of the following values at the IRR is the opposite of the first value
+			npv, err = NPV(irr, values[1:])
+			require.NoError(t, err)
+			requireDecimal(t, values[0].Neg().String(), npv)
+		})
+	}
+}
+
+func TestXNPVXIRR(t *testing.T) {
+	values := parseAll("-10000", "2750", "4250", "3250", "2750")
+	dates := parseDates("2008-01-01", "2008-03-01", "2008-10-30", "2009-02-15", "2009-04-01")
+
+	xnpv, err := XNPV(udecimal.MustParse("0.09"), values, dates)
+	require.NoError(t, err)
+	requireDecimal(t, "2086.6476020315", xnpv)
+
+	xirr, err := XIRR(values, dates, udecimal.MustParse("0.1"))
+	require.NoError(t, err)
+	requireDecimal(t, "0.3733625335", xirr)
+
+	xnpv, err = XNPV(xirr, values, dates)
+	require.NoError(t, err)
+	requireDecimal(t, "0", xnpv)
+
+	// the time of day and the time zone are ignored
+	shifted := make([]time.Time, len(dates))
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	for i, d := range dates {
+		shifted[i] = time.Date(d.Year(), d.Month(), d.Day(), 23, 59, 0, 0, loc)
+	}
+
+	xirr2, err := XIRR(values, shifted, udecimal.MustParse("0.1"))
+	require.NoError(t, err)
+	require.Equal(t, xirr, xirr2)
+
+	// yearly cash flows on a 365-day basis are the same as IRR
+	xirr, err = XIRR(parseAll("-100", "110"), parseDates("2023-01-01", "2024-01-01"), udecimal.MustParse("0.1"))
+	require.NoError(t, err)
+	requireDecimal(t, "0.1", xirr)
+
+	xnpv, err = XNPV(udecimal.MustParse("0.1"), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "0", xnpv.String())
+}
+
+func TestCashFlowErrors(t *testing.T) {
+	r := udecimal.MustParse("0.1")
+
+	_, err := NPV(udecimal.MustParse("-1"), parseAll("1"))
+	require.Equal(t, ErrInvalidRate, err)
+
+	_, err = IRR(parseAll("100", "200"), r)
+	require.Equal(t, ErrInvalidCashFlows, err)
+
+	_, err = IRR(nil, r)
+	require.Equal(t, ErrInvalidCashFlows, err)
+
+	// -1 + x - x^2 is always negative
+	_, err = IRR(parseAll("-1", "1", "-1"), r)
+	require.Equal(t, ErrNoConvergence, err)
+
+	_, err = XNPV(r, parseAll("-1", "2"), parseDates("2024-01-01"))
+	require.Equal(t, ErrLengthMismatch, err)
+
+	_, err = XNPV(udecimal.MustParse("-2"), parseAll("-1", "2"), parseDates("2024-01-01", "2024-06-01"))
+	require.Equal(t, ErrInvalidRate, err)
+
+	_, err = XIRR(parseAll("-1", "2"), parseDates("2024-01-01", "2023-12-31"), r)
+	require.Equal(t, ErrInvalidDates, err)
+
+	_, err = XIRR(parseAll("1", "2"), parseDates("2024-01-01", "2024-06-01"), r)
+	require.Equal(t, ErrInvalidCashFlows, err)
+}
@@ -0,0 +1,95 @@
+package finance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/markovichecha/udecimal"
+)
+
+func ExamplePMT() {
+	// monthly payment of a 30-year loan of 200,000 at 6% per year
+	rate := udecimal.MustParse("0.06").MustDiv(udecimal.MustParse("12"))
+	pmt, _ := PMT(rate, udecimal.MustParse("360"), udecimal.MustParse("200000"), udecimal.Zero, AtEnd)
+	fmt.Println(pmt.Round(2, udecimal.RoundHalfEven))
+	// Output:
+	// -1199.1
+}
+
+func ExampleRATE() {
+	r, _ := RATE(udecimal.MustParse("48"), udecimal.MustParse("-200"), udecimal.MustParse("8000"), udecimal.Zero, AtEnd, udecimal.MustParse("0.1"))
+	fmt.Println(r.Round(10, udecimal.RoundHalfEven))
+
+	// payments that can never pay off the loan
+	_, err := RATE(udecimal.MustParse("48"), udecimal.MustParse("200"), udecimal.MustParse("8000"), udecimal.Zero, AtEnd, udecimal.MustParse("0.1"))
+	fmt.Println(err)
+	// Output:
+	// 0.0077014725
+	// finance: solver did not converge
+}
+
+func ExampleIRR() {
+	values := []udecimal.Decimal{
+		udecimal.MustParse("-70000"),
+		udecimal.MustParse("12000"),
+		udecimal.MustParse("15000"),
+		udecimal.MustParse("18000"),
+		udecimal.MustParse("21000"),
+		udecimal.MustParse("26000"),
+	}
+
+	irr, _ := IRR(values, udecimal.MustParse("0.1"))
+	fmt.Println(irr.Round(10, udecimal.RoundHalfEven))
+	// Output:
+	// 0.086630948
+}
+
+func ExampleXIRR() {
+	values := []udecimal.Decimal{
+		udecimal.MustParse("-10000"),
+		udecimal.MustParse("2750"),
+		udecimal.MustParse("4250"),
+		udecimal.MustParse("3250"),
+		udecimal.MustParse("2750"),
+	}
+
+	dates := []time.Time{
+		time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, 10, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, 2, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	xirr, _ := XIRR(values, dates, udecimal.MustParse("0.1"))
+	fmt.Println(xirr.Round(10, udecimal.RoundHalfEven))
+	// Output:
+	// 0.3733625335
+}
+
+func ExampleAmortize() {
+	// 1,000 at 12% per year repaid monthly over 3 months
+	rows, _ := Amortize(udecimal.MustParse("1000"), udecimal.MustParse("0.12"), 3, DefaultAmortizeOptions())
+	for _, r := range rows {
+		fmt.Println(r.Period, r.Payment.StringFixed(2), r.Interest.StringFixed(2), r.Principal.StringFixed(2), r.Balance.StringFixed(2))
+	}
+
+	// interest-only for 2 quarters, then repaid down to a balloon of 500 paid with the last payment
+	opts := DefaultAmortizeOptions()
+	opts.Frequency = Quarterly
+	opts.InterestOnlyPeriods = 2
+	opts.Balloon = udecimal.MustParse("500")
+
+	rows, _ = Amortize(udecimal.MustParse("2000"), udecimal.MustParse("0.08"), 4, opts)
+	for _, r := range rows {
+		fmt.Println(r.Period, r.Payment.StringFixed(2), r.Interest.StringFixed(2), r.Principal.StringFixed(2), r.Balance.StringFixed(2))
+	}
+	// Output:
+	// 1 340.02 10.00 330.02 669.98
+	// 2 340.02 6.70 333.32 336.66
+	// 3 340.03 3.37 336.66 0.00
+	// 1 40.00 40.00 0.00 2000.00
+	// 2 40.00 40.00 0.00 2000.00
+	// 3 782.57 40.00 742.57 1257.43
+	// 4 1282.58 25.15 1257.43 0.00
+}
@@ -0,0 +1,414 @@
+// Package finance provides the time-value-of-money functions of spreadsheets
+// (PV, FV, PMT, IPMT, PPMT, NPER, RATE, NPV, IRR, XNPV and XIRR) on [udecimal.Decimal].
+//
+// The functions follow the Excel conventions: money paid out is negative, money received is positive,
+// and rates are per period (e.g. 0.06/12 for a yearly rate of 6% paid monthly).
+// Powers with an integer number of periods are computed with [udecimal.Decimal.PowInt32],
+// fractional powers and logarithms with [udecimal.Decimal.Pow] and [udecimal.Decimal.Ln].
+// Like the udecimal package, intermediate results and the final result are truncated to the default precision.
+//
+// RATE, IRR and XIRR are solved with Newton's method starting from a guess,
+// and return [ErrNoConvergence] when the solver doesn't converge.
+//
+// [Amortize] builds a loan amortization schedule where every amount is rounded to the minor unit of the currency.
+package finance
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/markovichecha/udecimal"
+)
+
+var (
+	// ErrNoConvergence is returned when RATE, IRR or XIRR doesn't converge, try another guess
+	ErrNoConvergence = fmt.Errorf("finance: solver did not converge")
+
+	// ErrInvalidTiming is returned when the payment timing is unknown
+	ErrInvalidTiming = fmt.Errorf("finance: invalid payment timing")
+
+	// ErrInvalidPeriod is returned when the period of IPMT or PPMT is not between 1 and nper
+	ErrInvalidPeriod = fmt.Errorf("finance: period must be between 1 and nper")
+
+	// ErrInvalidRate is returned when the rate is less than or equal to -1
+	ErrInvalidRate = fmt.Errorf("finance: rate must be greater than -1")
+
+	// ErrNoSolution is returned when NPER has no solution for the given arguments
+	ErrNoSolution = fmt.Errorf("finance: no solution")
+)
+
+var (
+	one = udecimal.One
+	two = udecimal.MustFromUint64(2, 0)
+)
+
+// PaymentTiming specifies whether payments are due at the end or at the beginning of each period.
+// It's the type argument (0 or 1) of the spreadsheet functions.
+type PaymentTiming uint8
+
+const (
+	// AtEnd means payments are due at the end of each period (type = 0).
+	AtEnd PaymentTiming = iota
+
+	// AtBeginning means payments are due at the beginning of each period (type = 1).
+	AtBeginning
+)
+
+// FV returns the future value of an investment with periodic constant payments and a constant interest rate.
+//
+//	FV = -(pv*(1+rate)^nper + pmt*(1+rate*type)*((1+rate)^nper-1)/rate)
+//	FV = -(pv + pmt*nper) when rate = 0
+//
+// Example:
+//
+//	FV(0.005, 10, -200, -500, AtBeginning) = 2581.4033740601...
+func FV(rate, nper, pmt, pv udecimal.Decimal, timing PaymentTiming) (udecimal.Decimal, error) {
+	if err := checkArgs(rate, timing); err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	if rate.IsZero() {
+		return pv.Add(pmt.Mul(nper)).Neg(), nil
+	}
+
+	f, err := pow1p(rate, nper)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	annuity, err := annuityFactor(rate, nper, f, timing)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return pv.Mul(f).Add(pmt.Mul(annuity)).Neg(), nil
+}
+
+// PV returns the present value of an investment with periodic constant payments and a constant interest rate.
+//
+//	PV = -(fv + pmt*(1+rate*type)*((1+rate)^nper-1)/rate) / (1+rate)^nper
+//	PV = -(fv + pmt*nper) when rate = 0
+//
+// Example:
+//
+//	PV(0.08/12, 240, 500, 0, AtEnd) = -59777.145851188...
+func PV(rate, nper, pmt, fv udecimal.Decimal, timing PaymentTiming) (udecimal.Decimal, error) {
+	if err := checkArgs(rate, timing); err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	if rate.IsZero() {
+		return fv.Add(pmt.Mul(nper)).Neg(), nil
+	}
+
+	f, err := pow1p(rate, nper)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	annuity, err := annuityFactor(rate, nper, f, timing)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return fv.Add(pmt.Mul(annuity)).Neg().Div(f)
+}
+
+// PMT returns the constant payment per period of a loan or an investment.
+//
+//	PMT = -(pv*(1+rate)^nper + fv) * rate / ((1+rate*type)*((1+rate)^nper-1))
+//	PMT = -(pv + fv) / nper when rate = 0
+//
+// Returns [udecimal.ErrDivideByZero] if nper is zero.
+//
+// Example:
+//
+//	PMT(0.08/12, 10, 10000, 0, AtEnd) = -1037.0320893591...
+func PMT(rate, nper, pv, fv udecimal.Decimal, timing PaymentTiming) (udecimal.Decimal, error) {
+	if err := checkArgs(rate, timing); err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	if rate.IsZero() {
+		return pv.Add(fv).Neg().Div(nper)
+	}
+
+	f, err := pow1p(rate, nper)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	annuity, err := annuityFactor(rate, nper, f, timing)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return pv.Mul(f).Add(fv).Neg().Div(annuity)
+}
+
+// IPMT returns the interest part of the payment for the period per (starting from 1) of a loan or an investment.
+//
+// Returns [ErrInvalidPeriod] if per < 1 or per > nper.
+//
+// Example:
+//
+//	IPMT(0.1/12, 1, 36, 8000, 0, AtEnd) = -66.666666666...
+func IPMT(rate, per, nper, pv, fv udecimal.Decimal, timing PaymentTiming) (udecimal.Decimal, error) {
+	if per.LessThan(one) || per.GreaterThan(nper) {
+		return udecimal.Decimal{}, ErrInvalidPeriod
+	}
+
+	pmt, err := PMT(rate, nper, pv, fv, timing)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return ipmt(rate, per, pmt, pv, timing)
+}
+
+// PPMT returns the principal part of the payment for the period per (starting from 1) of a loan or an investment,
+// i.e. PMT - IPMT.
+//
+// Returns [ErrInvalidPeriod] if per < 1 or per > nper.
+//
+// Example:
+//
+//	PPMT(0.1/12, 1, 24, 2000, 0, AtEnd) = -75.623186008...
+func PPMT(rate, per, nper, pv, fv udecimal.Decimal, timing PaymentTiming) (udecimal.Decimal, error) {
+	if per.LessThan(one) || per.GreaterThan(nper) {
+		return udecimal.Decimal{}, ErrInvalidPeriod
+	}
+
+	pmt, err := PMT(rate, nper, pv, fv, timing)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	interest, err := ipmt(rate, per, pmt, pv, timing)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return pmt.Sub(interest), nil
+}
+
+// ipmt returns the interest part of the payment pmt for the period per
+func ipmt(rate, per, pmt, pv udecimal.Decimal, timing PaymentTiming) (udecimal.Decimal, error) {
+	// no interest is due yet for the first payment at the beginning of the period
+	if timing == AtBeginning && per.Equal(one) {
+		return udecimal.Zero, nil
+	}
+
+	// interest on the balance at the beginning of the period
+	balance, err := FV(rate, per.Sub(one), pmt, pv, timing)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	interest := balance.Mul(rate)
+	if timing == AtBeginning {
+		return interest.Div(one.Add(rate))
+	}
+
+	return interest, nil
+}
+
+// NPER returns the number of periods of a loan or an investment with periodic constant payments.
+//
+//	NPER = ln((pmt*(1+rate*type) - fv*rate) / (pmt*(1+rate*type) + pv*rate)) / ln(1+rate)
+//	NPER = -(pv + fv) / pmt when rate = 0
+//
+// Returns [ErrNoSolution] if the payment never pays off the loan (the argument of the logarithm is not positive).
+//
+// Example:
+//
+//	NPER(0.01, -100, -1000, 10000, AtBeginning) = 59.673865674...
+func NPER(rate, pmt, pv, fv udecimal.Decimal, timing PaymentTiming) (udecimal.Decimal, error) {
+	if err := checkArgs(rate, timing); err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	if rate.IsZero() {
+		if pmt.IsZero() {
+			return udecimal.Decimal{}, ErrNoSolution
+		}
+
+		return pv.Add(fv).Neg().Div(pmt)
+	}
+
+	z := pmt
+	if timing == AtBeginning {
+		z = pmt.Mul(one.Add(rate))
+	}
+
+	num := z.Sub(fv.Mul(rate))
+	den := z.Add(pv.Mul(rate))
+	if den.IsZero() || num.Sign()*den.Sign() <= 0 {
+		return udecimal.Decimal{}, ErrNoSolution
+	}
+
+	x, err := num.Div(den)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	lnX, err := x.Ln()
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	lnR, err := one.Add(rate).Ln()
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return lnX.Div(lnR)
+}
+
+// RATE returns the interest rate per period of a loan or an investment, i.e. the rate that solves
+//
+//	pv*(1+rate)^nper + pmt*(1+rate*type)*((1+rate)^nper-1)/rate + fv = 0
+//
+// with Newton's method starting from guess (0.1 is a good default).
+//
+// Returns [ErrNoConvergence] if the solver doesn't converge.
+//
+// Example:
+//
+//	RATE(48, -200, 8000, 0, AtEnd, 0.1) = 0.0077014724882...
+func RATE(nper, pmt, pv, fv udecimal.Decimal, timing PaymentTiming, guess udecimal.Decimal) (udecimal.Decimal, error) {
+	if timing > AtBeginning {
+		return udecimal.Decimal{}, ErrInvalidTiming
+	}
+
+	return solve(guess, func(r udecimal.Decimal) (udecimal.Decimal, udecimal.Decimal, error) {
+		return rateFunc(r, nper, pmt, pv, fv, timing)
+	})
+}
+
+// rateFunc returns the value and the derivative of the RATE equation at r
+func rateFunc(r, n, pmt, pv, fv udecimal.Decimal, timing PaymentTiming) (udecimal.Decimal, udecimal.Decimal, error) {
+	// F = (1+r)^n, F' = n*(1+r)^(n-1) = n*F/(1+r)
+	F, err := pow1p(r, n)
+	if err != nil {
+		return udecimal.Decimal{}, udecimal.Decimal{}, err
+	}
+
+	dF, err := n.Mul(F).Div(one.Add(r))
+	if err != nil {
+		return udecimal.Decimal{}, udecimal.Decimal{}, err
+	}
+
+	// g = ((1+r)^n-1)/r
+	g, dg, err := growthFactor(r, n, F, dF)
+	if err != nil {
+		return udecimal.Decimal{}, udecimal.Decimal{}, err
+	}
+
+	// A = (1+r*type)*g, A' = type*g + (1+r*type)*g'
+	A, dA := g, dg
+	if timing == AtBeginning {
+		A = one.Add(r).Mul(g)
+		dA = g.Add(one.Add(r).Mul(dg))
+	}
+
+	f := pv.Mul(F).Add(pmt.Mul(A)).Add(fv)
+	d := pv.Mul(dF).Add(pmt.Mul(dA))
+
+	return f, d, nil
+}
+
+// seriesThreshold is the value of |n*r| under which growthFactor uses the binomial series
+var seriesThreshold = udecimal.MustParse("0.1")
+
+// maxSeriesTerms is the maximum number of terms of the binomial series
+const maxSeriesTerms = 100
+
+// growthFactor returns g = ((1+r)^n-1)/r and its derivative g' = (F'-g)/r, where F = (1+r)^n and F' = dF/dr.
+// The derivative is meaningless if dF is not given.
+//
+// When r*n is small, (1+r)^n-1 loses most of its digits and the binomial series is used instead:
+//
+//	g  = sum(C(n, k+1) * r^k), k >= 0
+//	g' = sum(k * C(n, k+1) * r^(k-1)), k >= 1
+func growthFactor(r, n, F, dF udecimal.Decimal) (udecimal.Decimal, udecimal.Decimal, error) {
+	if r.Mul(n).Abs().GreaterThanOrEqual(seriesThreshold) {
+		g, err := F.Sub(one).Div(r)
+		if err != nil {
+			return udecimal.Decimal{}, udecimal.Decimal{}, err
+		}
+
+		dg, err := dF.Sub(g).Div(r)
+		if err != nil {
+			return udecimal.Decimal{}, udecimal.Decimal{}, err
+		}
+
+		return g, dg, nil
+	}
+
+	// c = C(n, k+1), p = r^(k-1)
+	g, dg := n, udecimal.Zero
+	c, p := n, one
+	for k := int64(1); k < maxSeriesTerms; k++ {
+		kk := udecimal.MustFromInt64(k, 0)
+
+		var err error
+		c, err = c.Mul(n.Sub(kk)).Div(kk.Add(one))
+		if err != nil {
+			return udecimal.Decimal{}, udecimal.Decimal{}, err
+		}
+
+		dTerm := kk.Mul(c).Mul(p)
+		term := c.Mul(p).Mul(r)
+		if term.IsZero() && dTerm.IsZero() {
+			break
+		}
+
+		g = g.Add(term)
+		dg = dg.Add(dTerm)
+		p = p.Mul(r)
+	}
+
+	return g, dg, nil
+}
+
+// annuityFactor returns (1+rate*type)*(f-1)/rate, where f = (1+rate)^nper
+func annuityFactor(rate, nper, f udecimal.Decimal, timing PaymentTiming) (udecimal.Decimal, error) {
+	a, _, err := growthFactor(rate, nper, f, udecimal.Zero)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	if timing == AtBeginning {
+		a = a.Mul(one.Add(rate))
+	}
+
+	return a, nil
+}
+
+// pow1p returns (1+rate)^n.
+// PowInt32 is used when n is an integer, Pow otherwise.
+func pow1p(rate, n udecimal.Decimal) (udecimal.Decimal, error) {
+	base := one.Add(rate)
+
+	if n.Trunc(0).Equal(n) {
+		e, err := n.Int64()
+		if err == nil && e >= math.MinInt32 && e <= math.MaxInt32 {
+			return base.PowInt32(int32(e)) //nolint:gosec // e fits into int32
+		}
+	}
+
+	return base.Pow(n)
+}
+
+func checkArgs(rate udecimal.Decimal, timing PaymentTiming) error {
+	if timing > AtBeginning {
+		return ErrInvalidTiming
+	}
+
+	if rate.LessThanOrEqual(one.Neg()) {
+		return ErrInvalidRate
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package finance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markovichecha/udecimal"
+)
+
+// results are compared after rounding to 10 digits, the expected values are computed with 60 significant digits
+const cmpPrec = 10
+
+func requireDecimal(t *testing.T, want string, got udecimal.Decimal) {
+	t.Helper()
+	require.Equal(t, want, got.Round(cmpPrec, udecimal.RoundHalfEven).String())
+}
+
+func TestFVPVPMT(t *testing.T) {
+	m := udecimal.MustParse
+	monthly := m("0.08").MustDiv(m("12"))
+
+	testcases := []struct {
+		name string
+		fn   func() (udecimal.Decimal, error)
+		want string
+	}{
+		{"FV", func() (udecimal.Decimal, error) { return FV(m("0.005"), m("10"), m("-200"), m("-500"), AtBeginning) }, "2581.4033740602"},
+		{"FV fractional nper", func() (udecimal.Decimal, error) { return FV(m("0.05"), m("2.5"), m("0"), m("-1000"), AtEnd) }, "1129.726321947"},
+		{"FV zero rate", func() (udecimal.Decimal, error) { return FV(m("0"), m("10"), m("-100"), m("-1000"), AtEnd) }, "2000"},
+		{"PV", func() (udecimal.Decimal, error) { return PV(monthly, m("240"), m("500"), m("0"), AtEnd) }, "-59777.145851188"},
+		{"PV zero rate", func() (udecimal.Decimal, error) { return PV(m("0"), m("10"), m("-100"), m("0"), AtBeginning) }, "1000"},
+		{"PMT", func() (udecimal.Decimal, error) { return PMT(monthly, m("10"), m("10000"), m("0"), AtEnd) }, "-1037.0320893592"},
+		{"PMT zero rate", func() (udecimal.Decimal, error) { return PMT(m("0"), m("10"), m("1000"), m("0"), AtEnd) }, "-100"},
+		{"NPER", func() (udecimal.Decimal, error) {
+			return NPER(m("0.01"), m("-100"), m("-1000"), m("10000"), AtBeginning)
+		}, "59.6738656743"},
+		{"NPER zero rate", func() (udecimal.Decimal, error) { return NPER(m("0"), m("-100"), m("1000"), m("0"), AtEnd) }, "10"},
+		{"IPMT", func() (udecimal.Decimal, error) {
+			return IPMT(m("0.1").MustDiv(m("12")), m("1"), m("36"), m("8000"), m("0"), AtEnd)
+		}, "-66.6666666667"},
+		{"IPMT beginning", func() (udecimal.Decimal, error) {
+			return IPMT(m("0.01"), m("3"), m("12"), m("1000"), m("0"), AtBeginning)
+		}, "-8.3318211362"},
+		{"IPMT first beginning", func() (udecimal.Decimal, error) {
+			return IPMT(m("0.01"), m("1"), m("12"), m("1000"), m("0"), AtBeginning)
+		}, "0"},
+		{"PPMT", func() (udecimal.Decimal, error) {
+			return PPMT(m("0.1").MustDiv(m("12")), m("1"), m("24"), m("2000"), m("0"), AtEnd)
+		}, "-75.6231860084"},
+		{"PPMT beginning", func() (udecimal.Decimal, error) {
+			return PPMT(m("0.01"), m("3"), m("12"), m("1000"), m("0"), AtBeginning)
+		}, "-79.6372765651"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.fn()
+			require.NoError(t, err)
+			requireDecimal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	m := udecimal.MustParse
+
+	// a loan paid off by PMT has no balance left
+	for _, timing := range []PaymentTiming{AtEnd, AtBeginning} {
+		for _, rate := range []string{"0.004", "0.01", "0.05", "-0.01"} {
+			t.Run(fmt.Sprintf("%s_%d", rate, timing), func(t *testing.T) {
+				pmt, err := PMT(m(rate), m("60"), m("25000"), m("-1000"), timing)
+				require.NoError(t, err)
+
+				fv, err := FV(m(rate), m("60"), pmt, m("25000"), timing)
+				require.NoError(t, err)
+				requireDecimal(t, "-1000", fv)
+
+				pv, err := PV(m(rate), m("60"), pmt, m("-1000"), timing)
+				require.NoError(t, err)
+				requireDecimal(t, "25000", pv)
+
+				nper, err := NPER(m(rate), pmt, m("25000"), m("-1000"), timing)
+				require.NoError(t, err)
+				requireDecimal(t, "60", nper)
+
+				r, err := RATE(m("60"), pmt, m("25000"), m("-1000"), timing, m("0.1"))
+				require.NoError(t, err)
+				requireDecimal(t, m(rate).String(), r)
+
+				// principal parts of a fully paid off loan add up to the loan
+				total := udecimal.Zero
+				for per := range 60 {
+					p, err := PPMT(m(rate), udecimal.MustFromInt64(int64(per+1), 0), m("60"), m("25000"), m("0"), timing)
+					require.NoError(t, err)
+					total = total.Add(p)
+				}
+
+				requireDecimal(t, "-25000", total)
+			})
+		}
+	}
+}
+
+func TestRATE(t *testing.T) {
+	m := udecimal.MustParse
+
+	testcases := []struct {
+		nper, pmt, pv, fv string
+		timing            PaymentTiming
+		guess             string
+		want              string
+	}{
+		{"48", "-200", "8000", "0", AtEnd, "0.1", "0.0077014725"},
+		{"48", "-200", "8000", "0", AtBeginning, "0.1", "0.0080529819"},
+		{"48", "-200", "8000", "0", AtEnd, "0", "0.0077014725"},
+		{"10.5", "-200", "1500", "0", AtEnd, "0", "0.0634313931"},
+		{"10", "-100", "1000", "0", AtEnd, "0.1", "0"},
+		{"360", "-2.7778", "1000", "0", AtEnd, "0.1", "0.0000000443"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%s_%s_%s_%d_%s", tc.nper, tc.pmt, tc.pv, tc.fv, tc.timing, tc.guess), func(t *testing.T) {
+			r, err := RATE(m(tc.nper), m(tc.pmt), m(tc.pv), m(tc.fv), tc.timing, m(tc.guess))
+			require.NoError(t, err)
+			requireDecimal(t, tc.want, r)
+		})
+	}
+}
+
+func TestTinyRate(t *testing.T) {
+	m := udecimal.MustParse
+
+	// ((1+rate)^nper-1)/rate loses most of its digits when rate is tiny, the binomial series keeps all of them
+	pmt, err := PMT(m("0.0000000001"), m("360"), m("1000"), m("0"), AtEnd)
+	require.NoError(t, err)
+	require.Equal(t, "-2.7777778279166669666", pmt.String())
+
+	r, err := RATE(m("360"), m("-2.7778"), m("1000"), m("0"), AtEnd, m("0.1"))
+	require.NoError(t, err)
+	require.Equal(t, "0.0000000443212121051", r.String())
+}
+
+func TestErrors(t *testing.T) {
+	m := udecimal.MustParse
+	one, ten := m("1"), m("10")
+
+	_, err := FV(m("0.1"), ten, one, one, PaymentTiming(2))
+	require.Equal(t, ErrInvalidTiming, err)
+
+	_, err = PV(m("-1"), ten, one, one, AtEnd)
+	require.Equal(t, ErrInvalidRate, err)
+
+	_, err = PMT(m("0"), m("0"), one, one, AtEnd)
+	require.Equal(t, udecimal.ErrDivideByZero, err)
+
+	_, err = IPMT(m("0.1"), m("0"), ten, one, one, AtEnd)
+	require.Equal(t, ErrInvalidPeriod, err)
+
+	_, err = PPMT(m("0.1"), m("11"), ten, one, one, AtEnd)
+	require.Equal(t, ErrInvalidPeriod, err)
+
+	// the payment doesn't even cover the interest
+	_, err = NPER(m("0.1"), m("-50"), m("1000"), m("0"), AtEnd)
+	require.Equal(t, ErrNoSolution, err)
+
+	_, err = NPER(m("0"), m("0"), m("1000"), m("0"), AtEnd)
+	require.Equal(t, ErrNoSolution, err)
+
+	_, err = RATE(ten, m("-100"), m("1000"), m("0"), PaymentTiming(2), m("0.1"))
+	require.Equal(t, ErrInvalidTiming, err)
+
+	// payments can never pay off the loan
+	_, err = RATE(ten, m("100"), m("1000"), m("0"), AtEnd, m("0.1"))
+	require.Equal(t, ErrNoConvergence, err)
+}
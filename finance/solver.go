@@ -0,0 +1,18 @@
+package finance
+
+import (
+	"github.com/markovichecha/udecimal"
+	"github.com/markovichecha/udecimal/internal/solver"
+)
+
+// solve finds a root of f with Newton's method starting from guess, see [solver.Newton].
+// f returns the value and the derivative of the function at r, an error when the rate
+// left the domain of f (e.g. rate <= -1) or the values overflow.
+func solve(guess udecimal.Decimal, f solver.Func) (udecimal.Decimal, error) {
+	r, ok := solver.Newton(guess, f)
+	if !ok {
+		return udecimal.Decimal{}, ErrNoConvergence
+	}
+
+	return r, nil
+}
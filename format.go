@@ -0,0 +1,427 @@
+package udecimal
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+var _ fmt.Formatter = (*Decimal)(nil)
+
+// Format implements the [fmt.Formatter] interface, so a Decimal can be printed with the fmt package
+// without converting it to a float.
+//
+// The supported verbs are:
+//
+//	%v, %s  the same as String(), the precision is ignored
+//	%f, %F  decimal point, no exponent, e.g. 123.456. The default precision is the precision of d
+//	%e, %E  scientific notation, e.g. 1.23456e+02. The default precision is the smallest number of digits needed
+//	%g, %G  %e for large or small exponents, %f otherwise. The precision is the number of significant digits
+//	%q      a double-quoted string of String()
+//	%#v     Go syntax, e.g. udecimal.MustParse("123.456")
+//
+// Precisions smaller than the precision of d round half to even, like the fmt package does for exact ties.
+// Width and the flags '+', '-', ' ' and '0' work as for floats.
+//
+// Example:
+//
+//	fmt.Sprintf("%.2f", MustParse("1.235"))   // 1.24
+//	fmt.Sprintf("%8.1f|", MustParse("-1.25")) //     -1.2|
+//	fmt.Sprintf("%+e", MustParse("123.456"))  // +1.23456e+02
+func (d Decimal) Format(s fmt.State, verb rune) {
+	var (
+		buf  [maxDecimalStringU128]byte
+		body []byte
+	)
+
+	prec, hasPrec := s.Precision()
+
+	switch verb {
+	case 'v', 's':
+		if verb == 'v' && s.Flag('#') {
+			body = append(buf[:0], "udecimal.MustParse("...)
+			body = strconv.AppendQuote(body, d.String())
+			body = append(body, ')')
+			writePadded(s, nil, body, false)
+
+			return
+		}
+
+		body = d.Abs().appendText(buf[:0])
+	case 'q':
+		body = strconv.AppendQuote(buf[:0], d.String())
+		writePadded(s, nil, body, false)
+
+		return
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+		digs := d.digits(buf[:0])
+
+		if !hasPrec {
+			prec = -1
+		}
+
+		body = digs.format(make([]byte, 0, maxDecimalStringU128), verb, prec)
+
+		// a number that rounds to zero is printed without sign
+		if digs.nd == 0 {
+			d = Zero
+		}
+	default:
+		fmt.Fprintf(s, "%%!%c(udecimal.Decimal=%s)", verb, d.String())
+		return
+	}
+
+	var sign []byte
+	switch {
+	case d.IsNeg():
+		sign = []byte{'-'}
+	case s.Flag('+'):
+		sign = []byte{'+'}
+	case s.Flag(' '):
+		sign = []byte{' '}
+	}
+
+	writePadded(s, sign, body, true)
+}
+
+// StringSci returns the decimal in scientific notation with a single digit before the decimal point
+// and all significant digits, like %e without precision. Trailing zeros are removed.
+//
+// Example:
+//
+//	123.456.StringSci() -> 1.23456e+02
+//	-0.005.StringSci() -> -5e-03
+//	0.StringSci() -> 0e+00
+func (d Decimal) StringSci() string {
+	return string(d.AppendSci(make([]byte, 0, maxDecimalStringU128)))
+}
+
+// AppendSci appends the decimal in scientific notation, as returned by StringSci, to b.
+func (d Decimal) AppendSci(b []byte) []byte {
+	var buf [maxDecimalStringU128]byte
+
+	if d.IsNeg() {
+		b = append(b, '-')
+	}
+
+	digs := d.digits(buf[:0])
+	return digs.fmtE(b, 'e', max(digs.nd-1, 0))
+}
+
+// StringEng returns the decimal in engineering notation: the exponent is a multiple of 3 and
+// there are 1 to 3 digits before the decimal point. All significant digits are kept.
+//
+// Example:
+//
+//	123456.StringEng() -> 123.456e+03
+//	-0.0123.StringEng() -> -12.3e-03
+//	1000.StringEng() -> 1e+03
+func (d Decimal) StringEng() string {
+	return string(d.AppendEng(make([]byte, 0, maxDecimalStringU128)))
+}
+
+// AppendEng appends the decimal in engineering notation, as returned by StringEng, to b.
+func (d Decimal) AppendEng(b []byte) []byte {
+	var buf [maxDecimalStringU128]byte
+
+	if d.IsNeg() {
+		b = append(b, '-')
+	}
+
+	digs := d.digits(buf[:0])
+
+	exp := digs.dp - 1
+	if digs.nd == 0 {
+		exp = 0
+	}
+
+	// round the exponent down to a multiple of 3
+	engExp := exp - ((exp%3)+3)%3
+
+	// move the decimal point to have exp-engExp+1 digits before it
+	shifted := digs
+	shifted.dp = exp - engExp + 1
+	if digs.nd == 0 {
+		shifted.dp = 0
+	}
+
+	b = shifted.fmtF(b, max(shifted.nd-shifted.dp, 0))
+	b = append(b, 'e')
+
+	return appendExponent(b, engExp)
+}
+
+// appendText appends the string representation of d to buf, like AppendText
+func (d Decimal) appendText(buf []byte) []byte {
+	if !d.coef.overflow() {
+		return d.appendBuffer(buf, true, false)
+	}
+
+	return append(buf, d.stringBigInt(true)...)
+}
+
+// writePadded writes sign and body to s, padded to the width of s.
+// Zero padding is only used for numbers, it's inserted between the sign and the digits.
+func writePadded(s fmt.State, sign, body []byte, numeric bool) {
+	width, ok := s.Width()
+	n := width - len(sign) - utf8.RuneCount(body)
+
+	if !ok || n <= 0 {
+		_, _ = s.Write(sign)
+		_, _ = s.Write(body)
+
+		return
+	}
+
+	padding := make([]byte, n)
+
+	switch {
+	case s.Flag('-'):
+		for i := range padding {
+			padding[i] = ' '
+		}
+
+		_, _ = s.Write(sign)
+		_, _ = s.Write(body)
+		_, _ = s.Write(padding)
+	case s.Flag('0') && numeric:
+		for i := range padding {
+			padding[i] = '0'
+		}
+
+		_, _ = s.Write(sign)
+		_, _ = s.Write(padding)
+		_, _ = s.Write(body)
+	default:
+		for i := range padding {
+			padding[i] = ' '
+		}
+
+		_, _ = s.Write(padding)
+		_, _ = s.Write(sign)
+		_, _ = s.Write(body)
+	}
+}
+
+// decimalDigits is the absolute value of a decimal as 0.d[0]d[1]...d[nd-1] * 10^dp,
+// without leading and trailing zeros. Zero has nd = 0 and dp = 0.
+type decimalDigits struct {
+	d  []byte
+	nd int
+	dp int
+}
+
+// digits returns the digits of |d|. The digits of the coefficient are written by appendBuffer
+// (or big.Int for overflowed coefficients) into buf.
+func (d Decimal) digits(buf []byte) decimalDigits {
+	if d.coef.IsZero() {
+		return decimalDigits{}
+	}
+
+	coef := Decimal{coef: d.coef}
+	if !coef.coef.overflow() {
+		buf = coef.appendBuffer(buf, false, false)
+	} else {
+		buf = append(buf, coef.coef.bigInt.String()...)
+	}
+
+	digs := decimalDigits{d: buf, nd: len(buf), dp: len(buf) - int(d.prec)}
+	digs.trim()
+
+	return digs
+}
+
+// trim removes the trailing zeros
+func (a *decimalDigits) trim() {
+	for a.nd > 0 && a.d[a.nd-1] == '0' {
+		a.nd--
+	}
+
+	if a.nd == 0 {
+		a.dp = 0
+	}
+}
+
+// round rounds a to nd digits (nd can be <= 0) half to even
+func (a *decimalDigits) round(nd int) {
+	if nd < 0 || nd >= a.nd {
+		if nd < 0 {
+			*a = decimalDigits{d: a.d}
+		}
+
+		return
+	}
+
+	if a.shouldRoundUp(nd) {
+		a.roundUp(nd)
+	} else {
+		a.nd = nd
+		a.trim()
+	}
+}
+
+func (a *decimalDigits) shouldRoundUp(nd int) bool {
+	if a.d[nd] == '5' && nd+1 == a.nd {
+		// exactly halfway, round to even. The digit before the first digit is 0
+		return nd > 0 && (a.d[nd-1]-'0')%2 == 1
+	}
+
+	return a.d[nd] >= '5'
+}
+
+func (a *decimalDigits) roundUp(nd int) {
+	i := nd - 1
+	for i >= 0 && a.d[i] == '9' {
+		i--
+	}
+
+	if i < 0 {
+		// all nines, e.g. 0.999 -> 1
+		a.d[0] = '1'
+		a.nd = 1
+		a.dp++
+
+		return
+	}
+
+	a.d[i]++
+	a.nd = i + 1
+}
+
+// format formats a with the verb and precision (-1 for the shortest exact representation),
+// following the rules of strconv.FormatFloat. a is rounded in place.
+func (a *decimalDigits) format(dst []byte, verb rune, prec int) []byte {
+	switch verb {
+	case 'e', 'E':
+		if prec < 0 {
+			prec = max(a.nd-1, 0)
+		} else {
+			a.round(prec + 1)
+		}
+
+		return a.fmtE(dst, byte(verb), prec)
+	case 'g', 'G':
+		shortest := prec < 0
+		if shortest {
+			prec = a.nd
+		} else {
+			if prec == 0 {
+				prec = 1
+			}
+
+			a.round(prec)
+		}
+
+		eprec := prec
+		if eprec > a.nd && a.nd >= a.dp {
+			eprec = a.nd
+		}
+
+		// like %g for floats, the shortest representation uses %e for exponents >= 6
+		if shortest {
+			eprec = 6
+		}
+
+		exp := a.dp - 1
+		if a.nd != 0 && (exp < -4 || exp >= eprec) {
+			if prec > a.nd {
+				prec = a.nd
+			}
+
+			return a.fmtE(dst, byte(verb)+'e'-'g', prec-1)
+		}
+
+		if prec > a.dp {
+			prec = a.nd
+		}
+
+		return a.fmtF(dst, max(prec-a.dp, 0))
+	default:
+		if prec < 0 {
+			prec = max(a.nd-a.dp, 0)
+		} else {
+			a.round(a.dp + prec)
+		}
+
+		return a.fmtF(dst, prec)
+	}
+}
+
+// fmtE formats a as d.ddddde±dd with prec digits after the decimal point
+func (a decimalDigits) fmtE(dst []byte, e byte, prec int) []byte {
+	ch := byte('0')
+	if a.nd != 0 {
+		ch = a.d[0]
+	}
+
+	dst = append(dst, ch)
+
+	if prec > 0 {
+		dst = append(dst, '.')
+
+		i := 1
+		m := min(a.nd, prec+1)
+		if i < m {
+			dst = append(dst, a.d[i:m]...)
+			i = m
+		}
+
+		for ; i <= prec; i++ {
+			dst = append(dst, '0')
+		}
+	}
+
+	dst = append(dst, e)
+
+	exp := a.dp - 1
+	if a.nd == 0 {
+		exp = 0
+	}
+
+	return appendExponent(dst, exp)
+}
+
+// appendExponent appends the sign and at least 2 digits of exp
+func appendExponent(dst []byte, exp int) []byte {
+	if exp < 0 {
+		dst = append(dst, '-')
+		exp = -exp
+	} else {
+		dst = append(dst, '+')
+	}
+
+	if exp < 10 {
+		dst = append(dst, '0')
+	}
+
+	return strconv.AppendInt(dst, int64(exp), 10)
+}
+
+// fmtF formats a as ddd.ddd with prec digits after the decimal point
+func (a decimalDigits) fmtF(dst []byte, prec int) []byte {
+	if a.dp > 0 {
+		m := min(a.nd, a.dp)
+		dst = append(dst, a.d[:m]...)
+
+		for ; m < a.dp; m++ {
+			dst = append(dst, '0')
+		}
+	} else {
+		dst = append(dst, '0')
+	}
+
+	if prec > 0 {
+		dst = append(dst, '.')
+
+		for i := 1; i <= prec; i++ {
+			ch := byte('0')
+			if j := a.dp + i - 1; 0 <= j && j < a.nd {
+				ch = a.d[j]
+			}
+
+			dst = append(dst, ch)
+		}
+	}
+
+	return dst
+}
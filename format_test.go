@@ -0,0 +1,196 @@
+package udecimal
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	testcases := []struct {
+		format string
+		in     string
+		want   string
+	}{
+		{"%v", "1.23", "1.23"},
+		{"%s", "-1.2300", "-1.23"},
+		{"%v", "0", "0"},
+		{"%.1v", "1.23", "1.23"},
+		{"%10v|", "-1.23", "     -1.23|"},
+		{"%-10s|", "1.23", "1.23      |"},
+		{"%010v", "-1.23", "-000001.23"},
+		{"%+v", "1.23", "+1.23"},
+		{"% v", "1.23", " 1.23"},
+		{"%+v", "0", "+0"},
+		{"%q", "-1.5", `"-1.5"`},
+		{"%8q", "1.5", `   "1.5"`},
+		{"%08q", "1.5", `   "1.5"`},
+		{"%#v", "-1.5", `udecimal.MustParse("-1.5")`},
+		{"%#v", "0", `udecimal.MustParse("0")`},
+		{"%f", "1.2300", "1.23"},
+		{"%f", "-123", "-123"},
+		{"%F", "0.000001", "0.000001"},
+		{"%.2f", "1.235", "1.24"},
+		{"%.2f", "1.225", "1.22"},
+		{"%.2f", "1.2251", "1.23"},
+		{"%.2f", "-1.235", "-1.24"},
+		{"%.0f", "0.5", "0"},
+		{"%.0f", "1.5", "2"},
+		{"%.0f", "2.5", "2"},
+		{"%.0f", "999.5", "1000"},
+		{"%.2f", "0.999", "1.00"},
+		{"%.2f", "-0.001", "0.00"},
+		{"%.2f", "-0.005", "0.00"},
+		{"%.2f", "-0.0051", "-0.01"},
+		{"%.5f", "1.5", "1.50000"},
+		{"%.25f", "1.1", "1.1000000000000000000000000"},
+		{"%.0f", "123", "123"},
+		{"%8.2f|", "3.14159", "    3.14|"},
+		{"%-8.2f|", "3.14159", "3.14    |"},
+		{"%08.2f", "-3.14159", "-0003.14"},
+		{"%-08.2f|", "-3.14159", "-3.14   |"},
+		{"%+.1f", "2", "+2.0"},
+		{"% .1f", "2", " 2.0"},
+		{"%+ .1f", "2", "+2.0"},
+		{"%2.1f", "123.45", "123.4"},
+		{"%e", "123.456", "1.23456e+02"},
+		{"%E", "-0.00123", "-1.23E-03"},
+		{"%e", "0", "0e+00"},
+		{"%.2e", "0", "0.00e+00"},
+		{"%.3e", "9.9996", "1.000e+01"},
+		{"%.0e", "25", "2e+01"},
+		{"%.0e", "35", "4e+01"},
+		{"%e", "1234567890123456789012345678901234567890", "1.23456789012345678901234567890123456789e+39"},
+		{"%.2e", "0.0000000000000000001", "1.00e-19"},
+		{"%12.2e|", "-1234", "   -1.23e+03|"},
+		{"%g", "1234567", "1.234567e+06"},
+		{"%g", "123456", "123456"},
+		{"%g", "0.0001234", "0.0001234"},
+		{"%g", "0.00001234", "1.234e-05"},
+		{"%g", "100", "100"},
+		{"%g", "0", "0"},
+		{"%G", "0.0000000001", "1E-10"},
+		{"%.3g", "1234.5", "1.23e+03"},
+		{"%.3g", "1.5", "1.5"},
+		{"%.3g", "0.012345", "0.0123"},
+		{"%.1g", "0.96", "1"},
+		{"%.0g", "25", "2e+01"},
+		{"%.10g", "123.456", "123.456"},
+		{"%d", "1.5", "%!d(udecimal.Decimal=1.5)"},
+		{"%x", "-1", "%!x(udecimal.Decimal=-1)"},
+
+		// overflowed coefficients
+		{"%v", "123456789012345678901234567890.123", "123456789012345678901234567890.123"},
+		{"%.1f", "-123456789012345678901234567890.25", "-123456789012345678901234567890.2"},
+		{"%.3e", "123456789012345678901234567890.123", "1.235e+29"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.format+"/"+tc.in, func(t *testing.T) {
+			require.Equal(t, tc.want, fmt.Sprintf(tc.format, MustParse(tc.in)))
+		})
+	}
+}
+
+func TestFormatPointer(t *testing.T) {
+	d := MustParse("1.5")
+	require.Equal(t, "1.50", fmt.Sprintf("%.2f", &d))
+	require.Equal(t, "[1.5 -2]", fmt.Sprintf("%v", []Decimal{d, MustParse("-2")}))
+}
+
+// values k/2^m are exact both as float64 and as Decimal, so the fmt package must format them the same way
+// when the precision is given. Without precision, floats are printed with 6 digits or the shortest representation
+// that round-trips, while a Decimal is printed exactly.
+func TestFormatFloatConsistency(t *testing.T) {
+	verbs := []string{"%.0f", "%.1f", "%.2f", "%.3f", "%.10f", "%.0e", "%.1e", "%.4e", "%.1g", "%.3g", "%.8g", "%.20g", "%.3G", "%+8.2f", "%-10.3e", "%012.4f", "% .5g", "%.2E"}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 20000 {
+		m := r.IntN(19)
+		k := r.Int64N(1<<40) - 1<<39
+		if r.IntN(4) == 0 {
+			k = r.Int64N(2000) - 1000
+		}
+
+		f := float64(k) / float64(int64(1)<<m)
+
+		d := MustParse(new(big.Rat).SetFloat64(f).FloatString(m))
+
+		for _, verb := range verbs {
+			want := fmt.Sprintf(verb, f)
+			if f == 0 || (f < 0 && isZeroString(strings.TrimLeft(want, " -"))) {
+				// Decimal has no negative zero
+				continue
+			}
+
+			require.Equal(t, want, fmt.Sprintf(verb, d), "%s %s", verb, strconv.FormatFloat(f, 'f', -1, 64))
+		}
+	}
+}
+
+func isZeroString(s string) bool {
+	for _, c := range s {
+		if c != '0' && c != '.' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestStringSciEng(t *testing.T) {
+	testcases := []struct {
+		in       string
+		sci, eng string
+	}{
+		{"0", "0e+00", "0e+00"},
+		{"1", "1e+00", "1e+00"},
+		{"12", "1.2e+01", "12e+00"},
+		{"123.456", "1.23456e+02", "123.456e+00"},
+		{"-0.005", "-5e-03", "-5e-03"},
+		{"0.1", "1e-01", "100e-03"},
+		{"0.0123", "1.23e-02", "12.3e-03"},
+		{"-0.0123", "-1.23e-02", "-12.3e-03"},
+		{"1000", "1e+03", "1e+03"},
+		{"10000", "1e+04", "10e+03"},
+		{"123456", "1.23456e+05", "123.456e+03"},
+		{"1.2300", "1.23e+00", "1.23e+00"},
+		{"0.0000000000000000001", "1e-19", "100e-21"},
+		{"1234567890123456789012345678901234567890", "1.23456789012345678901234567890123456789e+39", "1.23456789012345678901234567890123456789e+39"},
+		{"-123456789012345678901234567890.5", "-1.234567890123456789012345678905e+29", "-123.4567890123456789012345678905e+27"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			d := MustParse(tc.in)
+			require.Equal(t, tc.sci, d.StringSci())
+			require.Equal(t, tc.eng, d.StringEng())
+			require.Equal(t, "x"+tc.sci, string(d.AppendSci([]byte("x"))))
+			require.Equal(t, "x"+tc.eng, string(d.AppendEng([]byte("x"))))
+			require.Equal(t, fmt.Sprintf("%e", d), d.StringSci())
+		})
+	}
+}
+
+func TestStringSciRoundTrip(t *testing.T) {
+	ctx := DefaultContext().WithParseExponent(true)
+
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 10000 {
+		d, err := NewFromHiLo(r.IntN(2) == 0, r.Uint64N(1000), r.Uint64(), uint8(r.IntN(20))) //nolint:gosec // IntN(20) fits in uint8
+		require.NoError(t, err)
+
+		sci, err := ctx.Parse(d.StringSci())
+		require.NoError(t, err)
+		require.True(t, d.Equal(sci), "%s %s", d, d.StringSci())
+
+		eng, err := ctx.Parse(d.StringEng())
+		require.NoError(t, err)
+		require.True(t, d.Equal(eng), "%s %s", d, d.StringEng())
+	}
+}
@@ -0,0 +1,30 @@
+package fx
+
+import (
+	"fmt"
+
+	"github.com/markovichecha/udecimal"
+	"github.com/markovichecha/udecimal/money"
+)
+
+func ExampleTable_Convert() {
+	table := NewTable(money.USD)
+	_ = table.Set(Pair{Base: money.GBP, Quote: money.USD}, udecimal.MustParse("1.2700"), udecimal.MustParse("1.2702"))
+	_ = table.Set(Pair{Base: money.USD, Quote: money.JPY}, udecimal.MustParse("149.50"), udecimal.MustParse("149.52"))
+
+	// direct rate
+	c, _ := table.Convert(money.MustParse("100", "GBP"), money.USD, Bid, udecimal.RoundHalfEven)
+	fmt.Println(c.To, c.Path)
+
+	// inverse rate: selling USD at the bid means buying GBP at the ask of GBP/USD
+	c, _ = table.Convert(money.MustParse("100", "USD"), money.GBP, Bid, udecimal.RoundHalfEven)
+	fmt.Println(c.To, c.Path, c.Legs[0].Pair, c.Legs[0].Rate, c.Legs[0].Inverse)
+
+	// triangulated through USD and rounded once
+	c, _ = table.Convert(money.MustParse("100", "GBP"), money.JPY, Mid, udecimal.RoundHalfEven)
+	fmt.Println(c.To, c.Path, c.Rate())
+	// Output:
+	// 127.00 USD [GBP USD]
+	// 78.73 GBP [USD GBP] GBP/USD 1.2702 true
+	// 18989 JPY [GBP USD JPY] 189.892651
+}
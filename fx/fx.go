@@ -0,0 +1,333 @@
+// Package fx converts [money.Money] between currencies using a table of bid/ask rates.
+//
+// A rate for a pair can be used directly or inverted, and when no rate between two currencies
+// is known, the conversion is triangulated through the base currency of the table.
+// Every conversion is computed exactly as amount * (product of rates) and rounded only once,
+// to the minor unit of the target currency, with [udecimal.Decimal.MulDivRound].
+package fx
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/markovichecha/udecimal"
+	"github.com/markovichecha/udecimal/money"
+)
+
+var (
+	// ErrNoRate is returned when no rate, direct, inverse or triangulated, is known between two currencies
+	ErrNoRate = fmt.Errorf("fx: no rate")
+
+	// ErrInvalidRate is returned when a rate is not positive or the bid is greater than the ask
+	ErrInvalidRate = fmt.Errorf("fx: rates must be positive and bid must not be greater than ask")
+
+	// ErrInvalidPair is returned when a pair has the same base and quote currency or a zero currency
+	ErrInvalidPair = fmt.Errorf("fx: invalid currency pair")
+
+	// ErrInvalidSide is returned when the side is unknown
+	ErrInvalidSide = fmt.Errorf("fx: invalid side")
+)
+
+// Pair is a currency pair. A rate r for the pair means 1 Base = r Quote.
+type Pair struct {
+	Base, Quote money.Currency
+}
+
+// String returns the pair in the usual "BASE/QUOTE" form, e.g. "EUR/USD".
+func (p Pair) String() string {
+	return p.Base.Code() + "/" + p.Quote.Code()
+}
+
+// Rate is the bid and ask of a currency pair.
+type Rate struct {
+	Bid, Ask udecimal.Decimal
+}
+
+// Side specifies which rate of a pair is used for a conversion.
+type Side uint8
+
+const (
+	// Mid uses the average of the bid and the ask.
+	Mid Side = iota
+
+	// Bid uses the bid of the pair from the source to the target currency,
+	// i.e. the source currency is sold at the bid.
+	Bid
+
+	// Ask uses the ask of the pair from the source to the target currency,
+	// i.e. the source currency is bought at the ask.
+	Ask
+)
+
+// String returns the name of the side.
+func (s Side) String() string {
+	switch s {
+	case Mid:
+		return "mid"
+	case Bid:
+		return "bid"
+	case Ask:
+		return "ask"
+	default:
+		return fmt.Sprintf("Side(%d)", s)
+	}
+}
+
+// Table is a table of rates keyed by currency pair.
+//
+// A Table is safe for concurrent use, so rates can be updated while conversions are running.
+type Table struct {
+	mu    sync.RWMutex
+	base  money.Currency
+	rates map[Pair]Rate
+}
+
+// NewTable returns an empty table.
+// Conversions without a direct or inverse rate are triangulated through base.
+// If base is the zero Currency, conversions are never triangulated.
+func NewTable(base money.Currency) *Table {
+	return &Table{base: base, rates: make(map[Pair]Rate)}
+}
+
+// Base returns the currency used for triangulation.
+func (t *Table) Base() money.Currency {
+	return t.base
+}
+
+// Set sets the bid and ask of pair, replacing the previous rate if any.
+// The rate of the inverse pair is derived automatically and doesn't need to be set.
+//
+// Returns error if:
+//   - pair has the same base and quote currency or a zero currency ([ErrInvalidPair])
+//   - bid or ask is not positive or bid > ask ([ErrInvalidRate])
+func (t *Table) Set(pair Pair, bid, ask udecimal.Decimal) error {
+	if pair.Base == pair.Quote || pair.Base.IsZero() || pair.Quote.IsZero() {
+		return ErrInvalidPair
+	}
+
+	if bid.Sign() <= 0 || ask.Sign() <= 0 || bid.Cmp(ask) > 0 {
+		return ErrInvalidRate
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rates[pair] = Rate{Bid: bid, Ask: ask}
+
+	return nil
+}
+
+// SetMid sets both the bid and the ask of pair to rate.
+func (t *Table) SetMid(pair Pair, rate udecimal.Decimal) error {
+	return t.Set(pair, rate, rate)
+}
+
+// Get returns the rate that was set for pair. Derived rates are not returned.
+func (t *Table) Get(pair Pair) (Rate, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	r, ok := t.rates[pair]
+	return r, ok
+}
+
+// Delete removes the rate of pair.
+func (t *Table) Delete(pair Pair) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.rates, pair)
+}
+
+// Leg is one step of a conversion.
+type Leg struct {
+	// From and To are the currencies of this step.
+	From, To money.Currency
+
+	// Pair is the pair of the rate in the table. It's To/From when the rate is inverted.
+	Pair Pair
+
+	// Rate is the rate of Pair that was used (bid, ask or mid).
+	// When Inverse is true, the amount is divided by Rate instead of multiplied.
+	Rate udecimal.Decimal
+
+	// Inverse reports whether the rate of the inverse pair was used.
+	Inverse bool
+
+	// exact rate of the leg, Rate can be truncated for Mid
+	ratio ratio
+}
+
+// Conversion is the result of converting money to another currency.
+type Conversion struct {
+	// From is the original money.
+	From money.Money
+
+	// To is the converted money, rounded to the minor unit of the target currency.
+	To money.Money
+
+	// Side is the side of the rates that were used.
+	Side Side
+
+	// Path is the currencies the conversion went through, including the source and the target currency,
+	// e.g. [GBP USD JPY] when triangulating through USD.
+	Path []money.Currency
+
+	// Legs are the steps of the conversion, len(Legs) = len(Path) - 1.
+	Legs []Leg
+}
+
+// Rate returns the effective rate of the conversion, i.e. the product of the rates of all legs,
+// truncated to the default precision. It's for information only, the conversion itself is not
+// computed from this truncated rate.
+func (c Conversion) Rate() udecimal.Decimal {
+	r := newRatio()
+	for _, leg := range c.Legs {
+		r = r.mul(leg.ratio)
+	}
+
+	rate, err := r.num.Div(r.den)
+	if err != nil {
+		// unreachable, rates are positive
+		panic(err)
+	}
+
+	return rate
+}
+
+// Convert converts m to the currency to, using the given side of the rates,
+// and rounds the result to the minor unit of to with the given rounding mode.
+//
+// The rate is looked up in this order: the pair m.Currency/to, the inverse pair to/m.Currency,
+// then triangulation through the base currency, where each of the two legs can also be direct or inverse.
+// The result is computed exactly and rounded once, even when triangulating.
+//
+// Returns error if:
+//   - side is not valid ([ErrInvalidSide])
+//   - no rate is found ([ErrNoRate])
+//
+// Panics if mode is not a valid [udecimal.RoundingMode].
+func (t *Table) Convert(m money.Money, to money.Currency, side Side, mode udecimal.RoundingMode) (Conversion, error) {
+	if side > Ask {
+		return Conversion{}, ErrInvalidSide
+	}
+
+	path, legs, err := t.route(m.Currency, to, side)
+	if err != nil {
+		return Conversion{}, err
+	}
+
+	r := newRatio()
+	for _, leg := range legs {
+		r = r.mul(leg.ratio)
+	}
+
+	amount, err := m.Amount.MulDivRound(r.num, r.den, to.MinorUnits(), mode)
+	if err != nil {
+		return Conversion{}, err
+	}
+
+	return Conversion{
+		From: m,
+		To:   money.New(amount, to),
+		Side: side,
+		Path: path,
+		Legs: legs,
+	}, nil
+}
+
+// route finds the legs from the currency from to the currency to
+func (t *Table) route(from, to money.Currency, side Side) ([]money.Currency, []Leg, error) {
+	if from == to {
+		return []money.Currency{from}, nil, nil
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if leg, ok := t.leg(from, to, side); ok {
+		return []money.Currency{from, to}, []Leg{leg}, nil
+	}
+
+	if !t.base.IsZero() && from != t.base && to != t.base {
+		first, ok1 := t.leg(from, t.base, side)
+		second, ok2 := t.leg(t.base, to, side)
+		if ok1 && ok2 {
+			return []money.Currency{from, t.base, to}, []Leg{first, second}, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("%w: %s", ErrNoRate, Pair{Base: from, Quote: to})
+}
+
+// leg returns the direct or inverse leg from the currency from to the currency to.
+// Caller must hold t.mu.
+func (t *Table) leg(from, to money.Currency, side Side) (Leg, bool) {
+	pair := Pair{Base: from, Quote: to}
+	if r, ok := t.rates[pair]; ok {
+		rate, exact := r.side(side, false)
+		return Leg{From: from, To: to, Pair: pair, Rate: rate, ratio: exact}, true
+	}
+
+	pair = Pair{Base: to, Quote: from}
+	if r, ok := t.rates[pair]; ok {
+		rate, exact := r.side(side, true)
+		return Leg{From: from, To: to, Pair: pair, Rate: rate, Inverse: true, ratio: exact.inverse()}, true
+	}
+
+	return Leg{}, false
+}
+
+// side returns the rate used for the given side, both as a decimal and as an exact ratio.
+// Selling the base of the inverse pair at its bid means buying its quote at its ask, so bid and ask are swapped.
+func (r Rate) side(s Side, inverse bool) (udecimal.Decimal, ratio) {
+	if inverse && s != Mid {
+		s = Bid + Ask - s
+	}
+
+	switch s {
+	case Bid:
+		return r.Bid, ratioOf(r.Bid)
+	case Ask:
+		return r.Ask, ratioOf(r.Ask)
+	default:
+		// (bid + ask) / 2 is truncated when bid or ask already has 19 digits after the decimal point,
+		// the ratio is always exact
+		sum := r.Bid.Add(r.Ask)
+		mid, err := sum.Div(two)
+		if err != nil {
+			// unreachable, two is not zero
+			panic(err)
+		}
+
+		exact := ratioOf(sum)
+		exact.den = exact.den.Mul(two)
+
+		return mid, exact
+	}
+}
+
+var two = udecimal.MustFromUint64(2, 0)
+
+// ratio is an exact rational number num/den, where num and den are positive integers
+type ratio struct {
+	num, den udecimal.Decimal
+}
+
+func newRatio() ratio {
+	return ratio{num: udecimal.One, den: udecimal.One}
+}
+
+// ratioOf returns d as an exact ratio of two integers: d * 10^prec / 10^prec
+func ratioOf(d udecimal.Decimal) ratio {
+	prec := d.PrecUint()
+	return ratio{num: d.ShiftPointLeft(prec), den: udecimal.One.ShiftPointLeft(prec)}
+}
+
+func (r ratio) mul(o ratio) ratio {
+	return ratio{num: r.num.Mul(o.num), den: r.den.Mul(o.den)}
+}
+
+func (r ratio) inverse() ratio {
+	return ratio{num: r.den, den: r.num}
+}
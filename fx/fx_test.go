@@ -0,0 +1,216 @@
+package fx
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/markovichecha/udecimal"
+	"github.com/markovichecha/udecimal/money"
+)
+
+var (
+	eurusd = Pair{Base: money.EUR, Quote: money.USD}
+	usdjpy = Pair{Base: money.USD, Quote: money.JPY}
+	gbpusd = Pair{Base: money.GBP, Quote: money.USD}
+)
+
+func newTestTable(t *testing.T) *Table {
+	t.Helper()
+
+	table := NewTable(money.USD)
+	require.NoError(t, table.Set(eurusd, udecimal.MustParse("1.0850"), udecimal.MustParse("1.0852")))
+	require.NoError(t, table.Set(usdjpy, udecimal.MustParse("149.50"), udecimal.MustParse("149.52")))
+	require.NoError(t, table.Set(gbpusd, udecimal.MustParse("1.2700"), udecimal.MustParse("1.2702")))
+
+	return table
+}
+
+func TestSet(t *testing.T) {
+	table := NewTable(money.USD)
+	one := udecimal.One
+
+	require.Equal(t, ErrInvalidPair, table.Set(Pair{Base: money.USD, Quote: money.USD}, one, one))
+	require.Equal(t, ErrInvalidPair, table.Set(Pair{Base: money.USD}, one, one))
+	require.Equal(t, ErrInvalidRate, table.Set(eurusd, udecimal.Zero, one))
+	require.Equal(t, ErrInvalidRate, table.Set(eurusd, one, one.Neg()))
+	require.Equal(t, ErrInvalidRate, table.Set(eurusd, udecimal.MustParse("1.1"), one))
+
+	_, ok := table.Get(eurusd)
+	require.False(t, ok)
+
+	require.NoError(t, table.SetMid(eurusd, udecimal.MustParse("1.1")))
+	r, ok := table.Get(eurusd)
+	require.True(t, ok)
+	require.Equal(t, "1.1", r.Bid.String())
+	require.Equal(t, "1.1", r.Ask.String())
+
+	// inverse rates are derived, not stored
+	_, ok = table.Get(Pair{Base: money.USD, Quote: money.EUR})
+	require.False(t, ok)
+
+	table.Delete(eurusd)
+	_, ok = table.Get(eurusd)
+	require.False(t, ok)
+
+	require.Equal(t, money.USD, table.Base())
+	require.Equal(t, "EUR/USD", eurusd.String())
+}
+
+func TestConvert(t *testing.T) {
+	table := newTestTable(t)
+
+	testcases := []struct {
+		amount, from, to string
+		side             Side
+		want             string
+		path             string
+		inverse          []bool
+		rates            []string
+		rate             string
+	}{
+		{"100", "EUR", "USD", Mid, "108.51 USD", "[EUR USD]", []bool{false}, []string{"1.0851"}, "1.0851"},
+		{"100", "EUR", "USD", Bid, "108.50 USD", "[EUR USD]", []bool{false}, []string{"1.085"}, "1.085"},
+		{"100", "EUR", "USD", Ask, "108.52 USD", "[EUR USD]", []bool{false}, []string{"1.0852"}, "1.0852"},
+
+		// inverse: selling USD at the bid of USD/EUR means buying EUR at the ask of EUR/USD
+		{"100", "USD", "EUR", Bid, "92.15 EUR", "[USD EUR]", []bool{true}, []string{"1.0852"}, "0.9214891264283081459"},
+		{"100", "USD", "EUR", Mid, "92.16 EUR", "[USD EUR]", []bool{true}, []string{"1.0851"}, "0.9215740484747949497"},
+		{"100", "USD", "EUR", Ask, "92.17 EUR", "[USD EUR]", []bool{true}, []string{"1.085"}, "0.9216589861751152073"},
+
+		// triangulation through USD
+		{"100", "GBP", "JPY", Mid, "18989 JPY", "[GBP USD JPY]", []bool{false, false}, []string{"1.2701", "149.51"}, "189.892651"},
+		{"100", "GBP", "JPY", Bid, "18986 JPY", "[GBP USD JPY]", []bool{false, false}, []string{"1.27", "149.5"}, "189.865"},
+		{"100", "GBP", "JPY", Ask, "18992 JPY", "[GBP USD JPY]", []bool{false, false}, []string{"1.2702", "149.52"}, "189.920304"},
+		{"1000", "JPY", "EUR", Mid, "6.16 EUR", "[JPY USD EUR]", []bool{true, true}, []string{"149.51", "1.0851"}, "0.0061639626009952173"},
+
+		// same currency
+		{"12.345", "USD", "USD", Mid, "12.34 USD", "[USD]", nil, nil, "1"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%s_%s_%s", tc.amount, tc.from, tc.to, tc.side), func(t *testing.T) {
+			m := money.MustParse(tc.amount, tc.from)
+			c, err := table.Convert(m, money.MustParseCurrency(tc.to), tc.side, udecimal.RoundHalfEven)
+			require.NoError(t, err)
+
+			require.Equal(t, m, c.From)
+			require.Equal(t, tc.want, c.To.String())
+			require.Equal(t, tc.side, c.Side)
+			require.Equal(t, tc.path, fmt.Sprint(c.Path))
+			require.Len(t, c.Legs, len(c.Path)-1)
+			require.Equal(t, tc.rate, c.Rate().String())
+
+			for i, leg := range c.Legs {
+				require.Equal(t, c.Path[i], leg.From)
+				require.Equal(t, c.Path[i+1], leg.To)
+				require.Equal(t, tc.inverse[i], leg.Inverse)
+				require.Equal(t, tc.rates[i], leg.Rate.String())
+
+				if leg.Inverse {
+					require.Equal(t, Pair{Base: leg.To, Quote: leg.From}, leg.Pair)
+				} else {
+					require.Equal(t, Pair{Base: leg.From, Quote: leg.To}, leg.Pair)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	table := newTestTable(t)
+
+	_, err := table.Convert(money.MustParse("1", "EUR"), money.USD, Side(3), udecimal.RoundHalfEven)
+	require.Equal(t, ErrInvalidSide, err)
+
+	_, err = table.Convert(money.MustParse("1", "EUR"), money.CHF, Mid, udecimal.RoundHalfEven)
+	require.ErrorIs(t, err, ErrNoRate)
+	require.EqualError(t, err, "fx: no rate: EUR/CHF")
+
+	// no triangulation without a base currency
+	noBase := NewTable(money.Currency{})
+	require.NoError(t, noBase.Set(eurusd, udecimal.MustParse("1.0850"), udecimal.MustParse("1.0852")))
+	require.NoError(t, noBase.Set(usdjpy, udecimal.MustParse("149.50"), udecimal.MustParse("149.52")))
+
+	_, err = noBase.Convert(money.MustParse("1", "EUR"), money.JPY, Mid, udecimal.RoundHalfEven)
+	require.ErrorIs(t, err, ErrNoRate)
+}
+
+func TestConvertMidExact(t *testing.T) {
+	// the mid rate has 20 digits after the decimal point and is truncated in Leg.Rate,
+	// but the conversion uses the exact value
+	table := NewTable(money.Currency{})
+	require.NoError(t, table.Set(eurusd, udecimal.MustParse("1.0000000000000000001"), udecimal.MustParse("1.0000000000000000002")))
+
+	c, err := table.Convert(money.MustParse("50000000000000000000", "EUR"), money.USD, Mid, udecimal.RoundHalfEven)
+	require.NoError(t, err)
+	require.Equal(t, "1.0000000000000000001", c.Legs[0].Rate.String())
+	require.Equal(t, "50000000000000000007.50 USD", c.To.String())
+}
+
+func TestConvertRandom(t *testing.T) {
+	table := newTestTable(t)
+	currencies := []money.Currency{money.EUR, money.USD, money.JPY, money.GBP}
+
+	// exact rate of each currency in USD for the given side
+	rate := func(c money.Currency) (decimal.Decimal, decimal.Decimal) {
+		switch c {
+		case money.EUR:
+			return decimal.RequireFromString("1.0851"), decimal.NewFromInt(1)
+		case money.GBP:
+			return decimal.RequireFromString("1.2701"), decimal.NewFromInt(1)
+		case money.JPY:
+			return decimal.NewFromInt(1), decimal.RequireFromString("149.51")
+		default:
+			return decimal.NewFromInt(1), decimal.NewFromInt(1)
+		}
+	}
+
+	for range 2000 {
+		//nolint:gosec // it's fine to use math/rand in tests
+		from, to := currencies[rand.IntN(4)], currencies[rand.IntN(4)]
+		m := money.New(udecimal.MustFromInt64(rand.Int64N(1_000_000_000_000), uint8(rand.IntN(5))), from)
+
+		c, err := table.Convert(m, to, Mid, udecimal.RoundHalfUp)
+		require.NoError(t, err)
+
+		// amount * from/USD * USD/to, rounded once half away from zero
+		n1, d1 := rate(from)
+		n2, d2 := rate(to)
+		want := decimal.RequireFromString(m.Amount.String()).Mul(n1).Mul(d2).DivRound(d1.Mul(n2), int32(to.MinorUnits()))
+
+		require.Equal(t, want.StringFixed(int32(to.MinorUnits())), c.To.Amount.StringFixed(to.MinorUnits()), "%s -> %s", m, to)
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	table := newTestTable(t)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := range 100 {
+				var err error
+				if i%2 == 0 {
+					err = table.SetMid(eurusd, udecimal.MustFromInt64(int64(10000+j), 4))
+				} else {
+					_, err = table.Convert(money.MustParse("1", "EUR"), money.JPY, Bid, udecimal.RoundHalfEven)
+				}
+
+				// require can't be used outside the test goroutine
+				if err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,42 @@
+// Package solver finds the roots of functions of [udecimal.Decimal] for the finance and bond packages.
+package solver
+
+import "github.com/markovichecha/udecimal"
+
+// maxIterations is the maximum number of iterations of the solver
+const maxIterations = 100
+
+// tolerance is the step size at which the solver stops
+var tolerance = udecimal.MustParse("0.000000000000001")
+
+// Func returns the value and the derivative of a function at x.
+// An error means that x is outside the domain of the function or that the values overflow.
+type Func func(x udecimal.Decimal) (udecimal.Decimal, udecimal.Decimal, error)
+
+// Newton finds a root of f with Newton's method starting from guess.
+// It returns false when f fails, the derivative is zero or the root isn't found after 100 iterations.
+func Newton(guess udecimal.Decimal, f Func) (udecimal.Decimal, bool) {
+	x := guess
+	for range maxIterations {
+		y, dy, err := f(x)
+		if err != nil {
+			return udecimal.Decimal{}, false
+		}
+
+		if y.IsZero() {
+			return x, true
+		}
+
+		step, err := y.Div(dy)
+		if err != nil {
+			return udecimal.Decimal{}, false
+		}
+
+		x = x.Sub(step)
+		if step.Abs().LessThan(tolerance) {
+			return x, true
+		}
+	}
+
+	return udecimal.Decimal{}, false
+}
@@ -0,0 +1,58 @@
+package solver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markovichecha/udecimal"
+)
+
+var two = udecimal.MustParse("2")
+
+// square returns x^2 - c and its derivative 2x
+func square(c udecimal.Decimal) Func {
+	return func(x udecimal.Decimal) (udecimal.Decimal, udecimal.Decimal, error) {
+		return x.Mul(x).Sub(c), x.Mul(two), nil
+	}
+}
+
+func TestNewton(t *testing.T) {
+	testcases := []struct {
+		guess  string
+		c      string
+		want   string
+		wantOK bool
+	}{
+		{"1", "2", "1.4142135623730950488", true},
+		{"-1", "2", "-1.4142135623730950488", true},
+		{"3", "9", "3", true},
+		{"100", "0.25", "0.5", true},
+		{"0", "2", "", false},
+		{"1", "-1", "", false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%s", tc.guess, tc.c), func(t *testing.T) {
+			x, ok := Newton(udecimal.MustParse(tc.guess), square(udecimal.MustParse(tc.c)))
+			require.Equal(t, tc.wantOK, ok)
+			if ok {
+				// the last step is below the tolerance, so the root is within a few units of the last digit
+				diff := x.Sub(udecimal.MustParse(tc.want)).Abs()
+				require.True(t, diff.LessThan(tolerance), "got %s, want %s", x, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewtonError(t *testing.T) {
+	calls := 0
+	_, ok := Newton(udecimal.MustParse("1"), func(udecimal.Decimal) (udecimal.Decimal, udecimal.Decimal, error) {
+		calls++
+		return udecimal.Decimal{}, udecimal.Decimal{}, udecimal.ErrDivideByZero
+	})
+
+	require.False(t, ok)
+	require.Equal(t, 1, calls)
+}
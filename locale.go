@@ -0,0 +1,419 @@
+package udecimal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownLocale is returned by [LookupLocale] when the locale is not in the built-in table
+var ErrUnknownLocale = fmt.Errorf("unknown locale")
+
+// NegativePattern is how a [Locale] writes negative numbers
+type NegativePattern uint8
+
+const (
+	// NegativeMinus writes a minus sign before the number (and before the currency symbol), e.g. -1,234.56
+	NegativeMinus NegativePattern = iota
+
+	// NegativeParens encloses the number (and the currency symbol) in parentheses, as in accounting, e.g. (1,234.56)
+	NegativeParens
+)
+
+// Locale describes how numbers are written in a region: the separators, the digit grouping,
+// the placement of the currency symbol and the negative pattern.
+// Use [LookupLocale] for the built-in locales or fill the fields for a custom one.
+//
+// Spaces written by a locale are regular ASCII spaces. When parsing, a space separator
+// also matches the no-break space (U+00A0) and the narrow no-break space (U+202F).
+type Locale struct {
+	// Decimal is the decimal separator, e.g. "." or ",". Empty means ".".
+	Decimal string
+
+	// Group is the grouping separator, e.g. "," or ".". Empty disables grouping.
+	Group string
+
+	// PrimaryGroup is the size of the group next to the decimal separator, 0 disables grouping
+	PrimaryGroup uint8
+
+	// SecondaryGroup is the size of the other groups, 0 means the same as PrimaryGroup.
+	// e.g. 2 with PrimaryGroup 3 for the Indian lakh grouping 12,34,567
+	SecondaryGroup uint8
+
+	// Symbol is the currency symbol, e.g. "€". Empty writes the number only.
+	Symbol string
+
+	// SymbolAfter writes the symbol after the number instead of before it
+	SymbolAfter bool
+
+	// SymbolSpace writes a space between the symbol and the number
+	SymbolSpace bool
+
+	// Negative is the pattern of negative numbers
+	Negative NegativePattern
+
+	// MinFrac is the minimum number of digits after the decimal separator.
+	// The fractional part is padded with zeros like [Decimal.StringFixed], e.g. 2 for amounts in cents.
+	MinFrac uint8
+}
+
+// locales is the built-in locale table, keyed by the lowercase tag.
+// The currency symbol is not set, only its placement, see [Locale.WithSymbol].
+var locales = map[string]Locale{
+	"en-us": {Decimal: ".", Group: ",", PrimaryGroup: 3},
+	"en-gb": {Decimal: ".", Group: ",", PrimaryGroup: 3},
+	"en-in": {Decimal: ".", Group: ",", PrimaryGroup: 3, SecondaryGroup: 2},
+	"de-de": {Decimal: ",", Group: ".", PrimaryGroup: 3, SymbolAfter: true, SymbolSpace: true},
+	"de-ch": {Decimal: ".", Group: "'", PrimaryGroup: 3, SymbolSpace: true},
+	"fr-fr": {Decimal: ",", Group: " ", PrimaryGroup: 3, SymbolAfter: true, SymbolSpace: true},
+	"fr-ch": {Decimal: ",", Group: " ", PrimaryGroup: 3, SymbolAfter: true, SymbolSpace: true},
+	"it-it": {Decimal: ",", Group: ".", PrimaryGroup: 3, SymbolAfter: true, SymbolSpace: true},
+	"es-es": {Decimal: ",", Group: ".", PrimaryGroup: 3, SymbolAfter: true, SymbolSpace: true},
+	"nl-nl": {Decimal: ",", Group: ".", PrimaryGroup: 3, SymbolSpace: true},
+	"pt-br": {Decimal: ",", Group: ".", PrimaryGroup: 3, SymbolSpace: true},
+	"ja-jp": {Decimal: ".", Group: ",", PrimaryGroup: 3},
+}
+
+// LookupLocale returns the built-in locale with the given tag, e.g. "de-DE".
+// The tag is case-insensitive and '_' can be used instead of '-'.
+//
+// The built-in locales are en-US, en-GB, en-IN, de-DE, de-CH, fr-FR, fr-CH, it-IT, es-ES, nl-NL, pt-BR and ja-JP.
+// They have no currency symbol, use [Locale.WithSymbol] to set one.
+//
+// Returns [ErrUnknownLocale] if the locale is not in the table.
+func LookupLocale(tag string) (Locale, error) {
+	loc, ok := locales[strings.ToLower(strings.ReplaceAll(tag, "_", "-"))]
+	if !ok {
+		return Locale{}, fmt.Errorf("%w: %q", ErrUnknownLocale, tag)
+	}
+
+	return loc, nil
+}
+
+// MustLookupLocale is similar to [LookupLocale] but panics if the locale is unknown.
+func MustLookupLocale(tag string) Locale {
+	loc, err := LookupLocale(tag)
+	if err != nil {
+		panic(err)
+	}
+
+	return loc
+}
+
+// WithSymbol returns a copy of loc that writes the currency symbol s
+func (loc Locale) WithSymbol(s string) Locale {
+	loc.Symbol = s
+	return loc
+}
+
+// WithNegative returns a copy of loc with the negative pattern p
+func (loc Locale) WithNegative(p NegativePattern) Locale {
+	loc.Negative = p
+	return loc
+}
+
+// WithMinFrac returns a copy of loc that writes at least n digits after the decimal separator
+func (loc Locale) WithMinFrac(n uint8) Locale {
+	loc.MinFrac = n
+	return loc
+}
+
+// FormatLocale returns the decimal written in the locale loc.
+// Trailing zeros are removed like [Decimal.String], unless they are needed for loc.MinFrac.
+// The number is not rounded, round it first to limit the digits after the decimal separator.
+//
+// Example:
+//
+//	1234567.89.FormatLocale(de-DE) -> 1.234.567,89
+//	1234567.89.FormatLocale(en-IN) -> 12,34,567.89
+//	-1234.5.FormatLocale(en-US with symbol "$", NegativeParens and MinFrac 2) -> ($1,234.50)
+func (d Decimal) FormatLocale(loc Locale) string {
+	return string(d.AppendLocale(make([]byte, 0, maxDecimalStringU128), loc))
+}
+
+// AppendLocale appends the decimal written in the locale loc, as returned by FormatLocale, to b.
+// It doesn't allocate if b has enough capacity and the coefficient fits into 128 bits.
+func (d Decimal) AppendLocale(b []byte, loc Locale) []byte {
+	var buf [maxDecimalStringU128]byte
+
+	text := d.Abs().appendText(buf[:0])
+
+	intPart, frac := text, []byte(nil)
+	if i := bytes.IndexByte(text, '.'); i >= 0 {
+		intPart, frac = text[:i], text[i+1:]
+	}
+
+	neg := d.IsNeg()
+	parens := neg && loc.Negative == NegativeParens
+
+	switch {
+	case parens:
+		b = append(b, '(')
+	case neg:
+		b = append(b, '-')
+	}
+
+	if loc.Symbol != "" && !loc.SymbolAfter {
+		b = append(b, loc.Symbol...)
+		if loc.SymbolSpace {
+			b = append(b, ' ')
+		}
+	}
+
+	b = loc.appendGrouped(b, intPart)
+
+	if len(frac) > 0 || loc.MinFrac > 0 {
+		b = append(b, loc.decimalSep()...)
+		b = append(b, frac...)
+
+		for i := len(frac); i < int(loc.MinFrac); i++ {
+			b = append(b, '0')
+		}
+	}
+
+	if loc.Symbol != "" && loc.SymbolAfter {
+		if loc.SymbolSpace {
+			b = append(b, ' ')
+		}
+		b = append(b, loc.Symbol...)
+	}
+
+	if parens {
+		b = append(b, ')')
+	}
+
+	return b
+}
+
+// appendGrouped appends the integer digits with the group separator between the groups
+func (loc Locale) appendGrouped(b, digits []byte) []byte {
+	if loc.Group == "" || loc.PrimaryGroup == 0 {
+		return append(b, digits...)
+	}
+
+	primary, secondary := int(loc.PrimaryGroup), loc.secondaryGroup()
+
+	for i, c := range digits {
+		b = append(b, c)
+
+		// number of digits after c
+		r := len(digits) - 1 - i
+		if r == primary || (r > primary && (r-primary)%secondary == 0) {
+			b = append(b, loc.Group...)
+		}
+	}
+
+	return b
+}
+
+// decimalSep returns the decimal separator, "." if loc.Decimal is empty
+// so that the digits of the integer and fractional parts are never run together
+func (loc Locale) decimalSep() string {
+	if loc.Decimal == "" {
+		return "."
+	}
+
+	return loc.Decimal
+}
+
+func (loc Locale) secondaryGroup() int {
+	if loc.SecondaryGroup == 0 {
+		return int(loc.PrimaryGroup)
+	}
+
+	return int(loc.SecondaryGroup)
+}
+
+// ParseLocale parses a number written in the locale loc, as returned by [Decimal.FormatLocale].
+// The precision and the parse mode are the package defaults, like [Parse].
+//
+// The currency symbol of loc is optional, and so are the group separators. When they are present,
+// the groups must match the grouping of loc, so that e.g. "1.234" in de-DE is not read as 1.234.
+// Negative numbers can be written with a minus sign or in parentheses, whatever loc.Negative is.
+//
+// Returns error if:
+//  1. empty/invalid string ([ErrEmptyString], [ErrInvalidFormat])
+//  2. the number has more than 19 digits after the decimal separator ([ErrPrecOutOfRange])
+//  3. the number without separators and symbol exceeds maxStrLen ([ErrMaxStrLen])
+//
+// Example:
+//
+//	ParseLocale("1.234.567,89", de-DE) -> 1234567.89
+//	ParseLocale("(1 234,50 €)", fr-FR with symbol "€") -> -1234.5
+func ParseLocale(s string, loc Locale) (Decimal, error) {
+	if s == "" {
+		return Decimal{}, ErrEmptyString
+	}
+
+	plain, err := loc.normalize(make([]byte, 0, len(s)), s)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	neg, coef, prec, err := parseBint(plain, defaultPrec, defaultParseMode)
+	if errors.Is(err, ErrInvalidFormat) {
+		// report the input, not the normalized string
+		return Decimal{}, errInvalidFormat(unsafeStringToBytes(s))
+	}
+
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	return newDecimal(neg, coef, prec), nil
+}
+
+// MustParseLocale is similar to [ParseLocale] but panics instead of returning error.
+func MustParseLocale(s string, loc Locale) Decimal {
+	d, err := ParseLocale(s, loc)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// normalize appends s without the symbol and the group separators, and with '.' as the
+// decimal point to dst, so it can be parsed by parseBint
+func (loc Locale) normalize(dst []byte, s string) ([]byte, error) {
+	str := s
+
+	var neg bool
+	switch {
+	case len(str) >= 2 && str[0] == '(' && str[len(str)-1] == ')':
+		neg = true
+		str = str[1 : len(str)-1]
+	case len(str) > 0 && (str[0] == '-' || str[0] == '+'):
+		neg = str[0] == '-'
+		str = str[1:]
+	}
+
+	if loc.Symbol != "" {
+		if loc.SymbolAfter {
+			if cut, ok := strings.CutSuffix(str, loc.Symbol); ok {
+				str = cut[:len(cut)-spaceSuffixLen(cut)]
+			}
+		} else if cut, ok := strings.CutPrefix(str, loc.Symbol); ok {
+			str = cut[spacePrefixLen(cut):]
+		}
+	}
+
+	if str == "" {
+		return nil, errInvalidFormat(unsafeStringToBytes(s))
+	}
+
+	if neg {
+		dst = append(dst, '-')
+	}
+
+	grouped := loc.Group != "" && loc.PrimaryGroup > 0
+	primary, secondary := int(loc.PrimaryGroup), loc.secondaryGroup()
+	decimalSep := loc.decimalSep()
+
+	var (
+		run, first, seps int
+		inFrac           bool
+	)
+
+	for i := 0; i < len(str); {
+		c := str[i]
+
+		switch {
+		case c >= '0' && c <= '9':
+			dst = append(dst, c)
+			run++
+			i++
+		case !inFrac && strings.HasPrefix(str[i:], decimalSep):
+			if seps > 0 && run != primary {
+				return nil, errInvalidFormat(unsafeStringToBytes(s))
+			}
+
+			dst = append(dst, '.')
+			inFrac = true
+			i += len(decimalSep)
+		case !inFrac && grouped && loc.groupLen(str[i:]) > 0:
+			switch {
+			case run == 0:
+				return nil, errInvalidFormat(unsafeStringToBytes(s))
+			case seps == 0:
+				first = run
+			case run != secondary:
+				// groups between the first and the last one
+				return nil, errInvalidFormat(unsafeStringToBytes(s))
+			}
+
+			seps++
+			run = 0
+			i += loc.groupLen(str[i:])
+		default:
+			return nil, errInvalidFormat(unsafeStringToBytes(s))
+		}
+
+		if len(dst) > maxStrLen {
+			return nil, ErrMaxStrLen
+		}
+	}
+
+	if seps > 0 {
+		// the first group can be shorter than the others
+		maxFirst := secondary
+		if seps == 1 {
+			maxFirst = primary
+		}
+
+		if first > maxFirst || (!inFrac && run != primary) {
+			return nil, errInvalidFormat(unsafeStringToBytes(s))
+		}
+	}
+
+	return dst, nil
+}
+
+// groupLen returns the length of the group separator at the start of s, or 0 if s doesn't start with it
+func (loc Locale) groupLen(s string) int {
+	if isSpaceSep(loc.Group) {
+		return spacePrefixLen(s)
+	}
+
+	if strings.HasPrefix(s, loc.Group) {
+		return len(loc.Group)
+	}
+
+	return 0
+}
+
+// spaces that are accepted for each other when parsing: space, no-break space and narrow no-break space
+var localeSpaces = [...]string{" ", "\u00a0", "\u202f"}
+
+func isSpaceSep(s string) bool {
+	for _, sp := range localeSpaces {
+		if s == sp {
+			return true
+		}
+	}
+
+	return false
+}
+
+// spacePrefixLen returns the length of the space at the start of s, or 0 if there is none
+func spacePrefixLen(s string) int {
+	for _, sp := range localeSpaces {
+		if strings.HasPrefix(s, sp) {
+			return len(sp)
+		}
+	}
+
+	return 0
+}
+
+// spaceSuffixLen returns the length of the space at the end of s, or 0 if there is none
+func spaceSuffixLen(s string) int {
+	for _, sp := range localeSpaces {
+		if strings.HasSuffix(s, sp) {
+			return len(sp)
+		}
+	}
+
+	return 0
+}
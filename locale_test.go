@@ -0,0 +1,245 @@
+package udecimal
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupLocale(t *testing.T) {
+	for _, tag := range []string{"en-US", "en-GB", "en-IN", "de-DE", "de-CH", "fr-FR", "fr-CH", "it-IT", "es-ES", "nl-NL", "pt-BR", "ja-JP"} {
+		loc, err := LookupLocale(tag)
+		require.NoError(t, err, tag)
+		require.NotEmpty(t, loc.Decimal, tag)
+		require.Empty(t, loc.Symbol, tag)
+	}
+
+	loc, err := LookupLocale("de_ch")
+	require.NoError(t, err)
+	require.Equal(t, MustLookupLocale("de-CH"), loc)
+
+	_, err = LookupLocale("xx-XX")
+	require.ErrorIs(t, err, ErrUnknownLocale)
+
+	require.Panics(t, func() { MustLookupLocale("") })
+}
+
+func TestFormatLocale(t *testing.T) {
+	de := MustLookupLocale("de-DE")
+	fr := MustLookupLocale("fr-FR")
+	in := MustLookupLocale("en-IN")
+	ch := MustLookupLocale("de-CH")
+	us := MustLookupLocale("en-US")
+
+	testcases := []struct {
+		in   string
+		loc  Locale
+		want string
+	}{
+		{"1234567.89", de, "1.234.567,89"},
+		{"1234567.89", fr, "1 234 567,89"},
+		{"1234567.89", in, "12,34,567.89"},
+		{"1234567.89", ch, "1'234'567.89"},
+		{"1234567.89", us, "1,234,567.89"},
+		{"-1234567.89", de, "-1.234.567,89"},
+		{"0", de, "0"},
+		{"-0", de, "0"},
+		{"0.05", de, "0,05"},
+		{"-0.05", in, "-0.05"},
+		{"123", in, "123"},
+		{"1234", in, "1,234"},
+		{"100000", in, "1,00,000"},
+		{"10000000", in, "1,00,00,000"},
+		{"999", de, "999"},
+		{"1000", de, "1.000"},
+		{"1.500", de, "1,5"},
+		{"1.5", de.WithMinFrac(2), "1,50"},
+		{"1.505", de.WithMinFrac(2), "1,505"},
+		{"12", de.WithMinFrac(2), "12,00"},
+		{"1234.5", de.WithSymbol("€").WithMinFrac(2), "1.234,50 €"},
+		{"-1234.5", de.WithSymbol("€").WithMinFrac(2), "-1.234,50 €"},
+		{"-1234.5", de.WithSymbol("€").WithNegative(NegativeParens), "(1.234,5 €)"},
+		{"1234.5", us.WithSymbol("$").WithMinFrac(2), "$1,234.50"},
+		{"-1234.5", us.WithSymbol("$").WithMinFrac(2), "-$1,234.50"},
+		{"-1234.5", us.WithSymbol("$").WithNegative(NegativeParens).WithMinFrac(2), "($1,234.50)"},
+		{"1234.5", us.WithNegative(NegativeParens), "1,234.5"},
+		{"-1234567", in.WithSymbol("₹"), "-₹12,34,567"},
+		{"1234.56", ch.WithSymbol("CHF"), "CHF 1'234.56"},
+		{"-1234.56", fr.WithSymbol("€").WithNegative(NegativeParens), "(1 234,56 €)"},
+		{"1234567.89", Locale{Decimal: "."}, "1234567.89"},
+		{"1234567.89", Locale{Decimal: ",", Group: " ", PrimaryGroup: 3}, "1 234 567,89"},
+		{"1234567.891", Locale{Decimal: "·", Group: "", PrimaryGroup: 3}, "1234567·891"},
+		{"123456789012345678901234567890123456789.12", us, "123,456,789,012,345,678,901,234,567,890,123,456,789.12"},
+		{"-1234567890123456789012345678901234567890.1", de, "-1.234.567.890.123.456.789.012.345.678.901.234.567.890,1"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s %+v", tc.in, tc.loc), func(t *testing.T) {
+			d := MustParse(tc.in)
+			require.Equal(t, tc.want, d.FormatLocale(tc.loc))
+			require.Equal(t, "x"+tc.want, string(d.AppendLocale([]byte("x"), tc.loc)))
+
+			got, err := ParseLocale(tc.want, tc.loc)
+			require.NoError(t, err)
+			require.True(t, d.Equal(got), "%s != %s", d, got)
+		})
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	de := MustLookupLocale("de-DE").WithSymbol("€")
+	in := MustLookupLocale("en-IN")
+	fr := MustLookupLocale("fr-FR")
+	us := MustLookupLocale("en-US").WithSymbol("$")
+
+	testcases := []struct {
+		s       string
+		loc     Locale
+		want    string
+		wantErr error
+	}{
+		{"1.234.567,89", de, "1234567.89", nil},
+		{"1234567,89", de, "1234567.89", nil},
+		{"1.234", de, "1234", nil},
+		{"-1.234,50", de, "-1234.5", nil},
+		{"+1.234,50", de, "1234.5", nil},
+		{"1.234,50 €", de, "1234.5", nil},
+		{"1.234,50€", de, "1234.5", nil},
+		{"1.234,50 €", de, "1234.5", nil},
+		{"-1.234,50 €", de, "-1234.5", nil},
+		{"(1.234,50 €)", de, "-1234.5", nil},
+		{"(1.234,50)", de, "-1234.5", nil},
+		{"0,0000000000000000001", de, "0.0000000000000000001", nil},
+		{"12,34,567.89", in, "1234567.89", nil},
+		{"1,00,000", in, "100000", nil},
+		{"123,456", in, "123456", nil},
+		{"1 234 567,89", fr, "1234567.89", nil},
+		{"1 234 567,89", fr, "1234567.89", nil},
+		{"$1,234.50", us, "1234.5", nil},
+		{"-$1,234.50", us, "-1234.5", nil},
+		{"($1,234.50)", us, "-1234.5", nil},
+		{"$ 1,234.50", us, "1234.5", nil},
+		{"", de, "", ErrEmptyString},
+		{"1.23", de, "", ErrInvalidFormat},
+		{"12.34.567", de, "", ErrInvalidFormat},
+		{"1234.567", de, "", ErrInvalidFormat},
+		{".123", de, "", ErrInvalidFormat},
+		{"1..234", de, "", ErrInvalidFormat},
+		{"1.234,", de, "", ErrInvalidFormat},
+		{",5", de, "", ErrInvalidFormat},
+		{"1,2,3", de, "", ErrInvalidFormat},
+		{"1.234,5.6", de, "", ErrInvalidFormat},
+		{"€ 1", de, "", ErrInvalidFormat},
+		{"1 €€", de, "", ErrInvalidFormat},
+		{"(-1)", de, "", ErrInvalidFormat},
+		{"--1", de, "", ErrInvalidFormat},
+		{"(1", de, "", ErrInvalidFormat},
+		{"()", de, "", ErrInvalidFormat},
+		{"€", de, "", ErrInvalidFormat},
+		{"1,5 $", de, "", ErrInvalidFormat},
+		{"123,45,678", in, "", ErrInvalidFormat},
+		{"1,234,567", in, "", ErrInvalidFormat},
+		{"1234,567", in, "", ErrInvalidFormat},
+		{"1,23", in, "", ErrInvalidFormat},
+		{"1,234.567.8", in, "", ErrInvalidFormat},
+		{"1 234,5", de, "", ErrInvalidFormat},
+		{"1.234,5", fr, "", ErrInvalidFormat},
+		{"1,234.50 $", us, "", ErrInvalidFormat},
+		{"0,00000000000000000001", de, "", ErrPrecOutOfRange},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.s, func(t *testing.T) {
+			d, err := ParseLocale(tc.s, tc.loc)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, d.String())
+		})
+	}
+
+	require.Equal(t, MustParse("1234.5"), MustParseLocale("1.234,5", de))
+	require.Panics(t, func() { MustParseLocale("1,234.5", de) })
+}
+
+func TestParseLocaleErrorMessage(t *testing.T) {
+	_, err := ParseLocale("1.234,", MustLookupLocale("de-DE"))
+	require.EqualError(t, err, "invalid format: can't parse '1.234,'")
+}
+
+func TestParseLocaleMaxStrLen(t *testing.T) {
+	us := MustLookupLocale("en-US")
+
+	d := MustParse("1234567890123456789012345678901234567890")
+	s := d.FormatLocale(us)
+	require.Greater(t, len(s), 40)
+
+	_, err := ParseLocale(s, us)
+	require.NoError(t, err)
+
+	long := "1"
+	for range maxStrLen/3 + 1 {
+		long += ",000"
+	}
+
+	_, err = ParseLocale(long, us)
+	require.ErrorIs(t, err, ErrMaxStrLen)
+}
+
+func TestLocaleRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+
+	locs := make([]Locale, 0, len(locales)*2)
+	for _, loc := range locales {
+		locs = append(locs, loc, loc.WithSymbol("CHF").WithNegative(NegativeParens).WithMinFrac(2))
+	}
+
+	for range 10000 {
+		d, err := NewFromHiLo(r.IntN(2) == 0, r.Uint64N(1000), r.Uint64(), uint8(r.IntN(20))) //nolint:gosec // IntN(20) fits in uint8
+		require.NoError(t, err)
+
+		loc := locs[r.IntN(len(locs))]
+
+		got, err := ParseLocale(d.FormatLocale(loc), loc)
+		require.NoError(t, err)
+		require.True(t, d.Equal(got), "%s %+v %s", d, loc, d.FormatLocale(loc))
+	}
+}
+
+func TestLocaleEmptyDecimal(t *testing.T) {
+	// an empty decimal separator falls back to "." instead of running the digits together
+	loc := Locale{Group: " ", PrimaryGroup: 3}
+
+	require.Equal(t, "1 234.56", MustParse("1234.56").FormatLocale(loc))
+	require.Equal(t, "-0.5", MustParse("-0.5").FormatLocale(loc))
+	require.Equal(t, "12.00", MustParse("12").FormatLocale(loc.WithMinFrac(2)))
+
+	for _, s := range []string{"1234.56", "-0.5", "12", "0.0000000000000000001"} {
+		d := MustParse(s)
+
+		got, err := ParseLocale(d.FormatLocale(loc), loc)
+		require.NoError(t, err)
+		require.True(t, d.Equal(got), s)
+	}
+
+	_, err := ParseLocale("1 234,56", loc)
+	require.ErrorIs(t, err, ErrInvalidFormat)
+}
+
+func TestAppendLocaleAllocs(t *testing.T) {
+	loc := MustLookupLocale("en-IN").WithSymbol("₹").WithNegative(NegativeParens).WithMinFrac(2)
+	d := MustParse("-123456789012345678901234567.5")
+	b := make([]byte, 0, 64)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		b = d.AppendLocale(b[:0], loc)
+	})
+
+	require.Zero(t, allocs)
+	require.Equal(t, "(₹12,34,56,78,90,12,34,56,78,90,12,34,567.50)", string(b))
+}
@@ -0,0 +1,116 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// moneyJSON is the JSON representation of Money
+type moneyJSON struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency Currency        `json:"currency"`
+}
+
+// nullValue represents the JSON null value.
+var nullValue = []byte("null")
+
+// MarshalJSON implements the [json.Marshaler] interface.
+// The amount is encoded as a string like [udecimal.Decimal.MarshalJSON]:
+//
+//	{"amount":"12.34","currency":"USD"}
+func (m Money) MarshalJSON() ([]byte, error) {
+	amount, err := m.Amount.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 0, len(amount)+32)
+	b = append(b, `{"amount":`...)
+	b = append(b, amount...)
+	b = append(b, `,"currency":"`...)
+	b = append(b, m.Currency.code...)
+	b = append(b, `"}`...)
+
+	return b, nil
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+// The amount can be either a JSON string or a JSON number.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullValue) {
+		return nil
+	}
+
+	var v moneyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("error unmarshaling to Money: %w", err)
+	}
+
+	if len(v.Amount) == 0 {
+		return fmt.Errorf("error unmarshaling to Money: missing amount")
+	}
+
+	var res Money
+	if err := res.Amount.UnmarshalJSON(v.Amount); err != nil {
+		return err
+	}
+
+	res.Currency = v.Currency
+	*m = res
+
+	return nil
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+// The result is the same as [Money.String], e.g. "12.30 USD".
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// The text is an amount followed by a space and an ISO 4217 code, e.g. "12.30 USD".
+// An amount without a code is decoded with the zero Currency.
+func (m *Money) UnmarshalText(data []byte) error {
+	amount, code, _ := bytes.Cut(data, []byte(" "))
+
+	var res Money
+	if err := res.Currency.UnmarshalText(code); err != nil {
+		return fmt.Errorf("error unmarshaling to Money: %w", err)
+	}
+
+	if err := res.Amount.UnmarshalText(amount); err != nil {
+		return err
+	}
+
+	*m = res
+
+	return nil
+}
+
+// Scan implements [sql.Scanner] interface.
+// The source must be the text form of Money, see [Money.UnmarshalText].
+// To store the amount and the currency in separate columns, scan into Money.Amount and Money.Currency instead.
+//
+// [sql.Scanner]: https://pkg.go.dev/database/sql#Scanner
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return m.UnmarshalText(v)
+	case string:
+		return m.UnmarshalText([]byte(v))
+	case nil:
+		return fmt.Errorf("money: can't scan nil to Money")
+	default:
+		return fmt.Errorf("money: can't scan %T to Money: %T is not supported", src, src)
+	}
+}
+
+// Value implements [driver.Valuer] interface.
+// The value is the text form of Money, see [Money.MarshalText].
+//
+// [driver.Valuer]: https://pkg.go.dev/database/sql/driver#Valuer
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
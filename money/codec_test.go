@@ -0,0 +1,139 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	testcases := []struct {
+		m    Money
+		want string
+	}{
+		{MustParse("12.34", "USD"), `{"amount":"12.34","currency":"USD"}`},
+		{MustParse("-0.5", "EUR"), `{"amount":"-0.5","currency":"EUR"}`},
+		{MustParse("1000", "JPY"), `{"amount":"1000","currency":"JPY"}`},
+		{MustParse("123456789012345678901234567890.123", "KWD"), `{"amount":"123456789012345678901234567890.123","currency":"KWD"}`},
+		{Money{}, `{"amount":"0","currency":""}`},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.want, func(t *testing.T) {
+			b, err := json.Marshal(tc.m)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, string(b))
+
+			var m Money
+			require.NoError(t, json.Unmarshal(b, &m))
+			require.True(t, tc.m.Equal(m))
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	testcases := []struct {
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{`{"amount":"12.34","currency":"USD"}`, "12.34 USD", false},
+		{`{"amount":12.34,"currency":"usd"}`, "12.34 USD", false},
+		{`{"currency":"USD","amount":"1"}`, "1.00 USD", false},
+		{`{"amount":"1","currency":"XYZ"}`, "", true},
+		{`{"amount":"1.2.3","currency":"USD"}`, "", true},
+		{`{"currency":"USD"}`, "", true},
+		{`{"amount":"1","currency":1}`, "", true},
+		{`[]`, "", true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.data, func(t *testing.T) {
+			var m Money
+			err := json.Unmarshal([]byte(tc.data), &m)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, m.String())
+		})
+	}
+
+	// null leaves the value unchanged
+	m := MustParse("1", "USD")
+	require.NoError(t, json.Unmarshal([]byte("null"), &m))
+	require.Equal(t, "1.00 USD", m.String())
+
+	// inside a struct
+	var s struct {
+		Price Money  `json:"price"`
+		Ptr   *Money `json:"ptr"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"price":{"amount":"9.99","currency":"GBP"},"ptr":null}`), &s))
+	require.Equal(t, "9.99 GBP", s.Price.String())
+	require.Nil(t, s.Ptr)
+}
+
+func TestText(t *testing.T) {
+	testcases := []struct {
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{"12.30 USD", "12.30 USD", false},
+		{"-7 jpy", "-7 JPY", false},
+		{"0.001 BHD", "0.001 BHD", false},
+		{"42", "42", false},
+		{"12.30 XYZ", "", true},
+		{"abc USD", "", true},
+		{"12.30  USD", "", true},
+		{"", "", true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.text, func(t *testing.T) {
+			var m Money
+			err := m.UnmarshalText([]byte(tc.text))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, m.String())
+
+			b, err := m.MarshalText()
+			require.NoError(t, err)
+			require.Equal(t, tc.want, string(b))
+		})
+	}
+}
+
+func TestSQL(t *testing.T) {
+	m := MustParse("12.3", "EUR")
+
+	v, err := m.Value()
+	require.NoError(t, err)
+	require.Equal(t, "12.30 EUR", v)
+
+	var got Money
+	require.NoError(t, got.Scan(v))
+	require.True(t, m.Equal(got))
+
+	require.NoError(t, got.Scan([]byte("5 JPY")))
+	require.Equal(t, "5 JPY", got.String())
+
+	// amount and currency stored in separate columns
+	var sep Money
+	require.NoError(t, sep.Amount.Scan("1.5"))
+	require.NoError(t, sep.Currency.Scan("USD"))
+	require.Equal(t, "1.50 USD", sep.String())
+
+	require.EqualError(t, got.Scan(nil), "money: can't scan nil to Money")
+	require.EqualError(t, got.Scan(1.5), "money: can't scan float64 to Money: float64 is not supported")
+	require.Error(t, got.Scan("1 XYZ"))
+}
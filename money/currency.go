@@ -0,0 +1,113 @@
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency.
+//
+// The zero value is not a valid currency, it's only used for the zero value of [Money].
+// Currencies are comparable with ==.
+type Currency struct {
+	code       string
+	minorUnits uint8
+}
+
+// Commonly used currencies.
+var (
+	USD = MustParseCurrency("USD")
+	EUR = MustParseCurrency("EUR")
+	GBP = MustParseCurrency("GBP")
+	JPY = MustParseCurrency("JPY")
+	CHF = MustParseCurrency("CHF")
+	CNY = MustParseCurrency("CNY")
+)
+
+// ParseCurrency returns the currency with the given ISO 4217 alphabetic code, e.g. "USD".
+// The code is case-insensitive.
+//
+// Returns [ErrUnknownCurrency] if the code is not an active ISO 4217 currency.
+func ParseCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(code)
+
+	minorUnits, ok := iso4217[code]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+
+	return Currency{code: code, minorUnits: minorUnits}, nil
+}
+
+// MustParseCurrency is similar to [ParseCurrency] but panics if the code is unknown.
+func MustParseCurrency(code string) Currency {
+	c, err := ParseCurrency(code)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// Code returns the ISO 4217 alphabetic code of the currency, e.g. "USD".
+func (c Currency) Code() string {
+	return c.code
+}
+
+// MinorUnits returns the number of digits after the decimal point of the currency's minor unit,
+// e.g. 2 for USD (cents) and 0 for JPY.
+func (c Currency) MinorUnits() uint8 {
+	return c.minorUnits
+}
+
+// IsZero reports whether c is the zero value.
+func (c Currency) IsZero() bool {
+	return c.code == ""
+}
+
+// String returns the ISO 4217 alphabetic code of the currency.
+func (c Currency) String() string {
+	return c.code
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (c Currency) MarshalText() ([]byte, error) {
+	return []byte(c.code), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// An empty text is decoded to the zero value.
+func (c *Currency) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*c = Currency{}
+		return nil
+	}
+
+	var err error
+	*c, err = ParseCurrency(string(data))
+	return err
+}
+
+// Scan implements [sql.Scanner] interface.
+//
+// [sql.Scanner]: https://pkg.go.dev/database/sql#Scanner
+func (c *Currency) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return c.UnmarshalText(v)
+	case string:
+		return c.UnmarshalText([]byte(v))
+	case nil:
+		return fmt.Errorf("money: can't scan nil to Currency")
+	default:
+		return fmt.Errorf("money: can't scan %T to Currency: %T is not supported", src, src)
+	}
+}
+
+// Value implements [driver.Valuer] interface.
+//
+// [driver.Valuer]: https://pkg.go.dev/database/sql/driver#Valuer
+func (c Currency) Value() (driver.Value, error) {
+	return c.code, nil
+}
@@ -0,0 +1,83 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCurrency(t *testing.T) {
+	testcases := []struct {
+		code       string
+		want       string
+		minorUnits uint8
+		wantErr    bool
+	}{
+		{"USD", "USD", 2, false},
+		{"usd", "USD", 2, false},
+		{"JPY", "JPY", 0, false},
+		{"KWD", "KWD", 3, false},
+		{"CLF", "CLF", 4, false},
+		{"", "", 0, true},
+		{"XAU", "", 0, true},
+		{"ABC", "", 0, true},
+		{"USDT", "", 0, true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.code, func(t *testing.T) {
+			c, err := ParseCurrency(tc.code)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrUnknownCurrency)
+				require.Panics(t, func() { _ = MustParseCurrency(tc.code) })
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, c.Code())
+			require.Equal(t, tc.want, c.String())
+			require.Equal(t, tc.minorUnits, c.MinorUnits())
+			require.False(t, c.IsZero())
+		})
+	}
+
+	require.Equal(t, USD, MustParseCurrency("usd"))
+	require.NotEqual(t, USD, EUR)
+	require.True(t, Currency{}.IsZero())
+}
+
+func TestISO4217Table(t *testing.T) {
+	for code, minorUnits := range iso4217 {
+		require.Len(t, code, 3)
+		require.Regexp(t, "^[A-Z]{3}$", code)
+		require.LessOrEqual(t, minorUnits, uint8(4), code)
+	}
+}
+
+func TestCurrencyCodec(t *testing.T) {
+	b, err := EUR.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "EUR", string(b))
+
+	var c Currency
+	require.NoError(t, c.UnmarshalText([]byte("eur")))
+	require.Equal(t, EUR, c)
+
+	require.NoError(t, c.UnmarshalText(nil))
+	require.True(t, c.IsZero())
+
+	require.ErrorIs(t, c.UnmarshalText([]byte("EURO")), ErrUnknownCurrency)
+
+	v, err := JPY.Value()
+	require.NoError(t, err)
+	require.Equal(t, "JPY", v)
+
+	require.NoError(t, c.Scan("GBP"))
+	require.Equal(t, GBP, c)
+
+	require.NoError(t, c.Scan([]byte("CHF")))
+	require.Equal(t, CHF, c)
+
+	require.EqualError(t, c.Scan(nil), "money: can't scan nil to Currency")
+	require.EqualError(t, c.Scan(1), "money: can't scan int to Currency: int is not supported")
+}
@@ -0,0 +1,44 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/markovichecha/udecimal"
+)
+
+func ExampleMoney_Add() {
+	a := MustParse("12.34", "USD")
+	b := MustParse("0.66", "USD")
+	fmt.Println(a.Add(b))
+
+	_, err := a.Add(MustParse("100", "JPY"))
+	fmt.Println(err, errors.Is(err, ErrCurrencyMismatch))
+	// Output:
+	// 13.00 USD <nil>
+	// money: currency mismatch: USD and JPY true
+}
+
+func ExampleMoney_RoundToMinorUnit() {
+	price := MustParse("19.99", "USD").Mul(udecimal.MustParse("1.0825"))
+	fmt.Println(price)
+	fmt.Println(price.RoundToMinorUnit(udecimal.RoundHalfEven))
+	fmt.Println(MustParse("1234.5", "JPY").RoundToMinorUnit(udecimal.RoundHalfUp))
+	// Output:
+	// 21.639175 USD
+	// 21.64 USD
+	// 1235 JPY
+}
+
+func ExampleMoney_Split() {
+	fmt.Println(MustParse("100", "USD").Split(3))
+	// Output:
+	// [33.34 USD 33.33 USD 33.33 USD] <nil>
+}
+
+func ExampleMoney_MarshalJSON() {
+	b, _ := MustParse("12.3", "EUR").MarshalJSON()
+	fmt.Println(string(b))
+	// Output:
+	// {"amount":"12.3","currency":"EUR"}
+}
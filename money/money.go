@@ -0,0 +1,228 @@
+// Package money provides [Money], a [udecimal.Decimal] amount paired with an ISO 4217 [Currency].
+//
+// Operations between two amounts (Add, Sub, Cmp) refuse to mix currencies and return a
+// [*CurrencyMismatchError] instead. The amount is never rounded implicitly, use
+// [Money.RoundToMinorUnit] to round it to the currency's minor unit, e.g. cents.
+//
+// # Codec
+//
+//   - Marshal/UnmarshalJSON: {"amount":"12.34","currency":"USD"}
+//   - Marshal/UnmarshalText: "12.34 USD"
+//   - SQL: Money is stored as its text form, Currency as its code.
+package money
+
+import (
+	"fmt"
+
+	"github.com/markovichecha/udecimal"
+)
+
+var (
+	// ErrUnknownCurrency is returned when a currency code is not an active ISO 4217 currency
+	ErrUnknownCurrency = fmt.Errorf("money: unknown currency")
+
+	// ErrCurrencyMismatch is matched by [*CurrencyMismatchError] with errors.Is
+	ErrCurrencyMismatch = fmt.Errorf("money: currency mismatch")
+)
+
+// CurrencyMismatchError is returned when an operation is applied to amounts in different currencies.
+type CurrencyMismatchError struct {
+	A, B Currency
+}
+
+// Error implements the error interface.
+func (e *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("money: currency mismatch: %s and %s", currencyName(e.A), currencyName(e.B))
+}
+
+// Is reports whether target is [ErrCurrencyMismatch].
+func (e *CurrencyMismatchError) Is(target error) bool {
+	return target == ErrCurrencyMismatch
+}
+
+func currencyName(c Currency) string {
+	if c.IsZero() {
+		return "<none>"
+	}
+
+	return c.code
+}
+
+// Money is an amount in a currency.
+//
+// The zero value is a zero amount without a currency.
+type Money struct {
+	Amount   udecimal.Decimal
+	Currency Currency
+}
+
+// New returns the money with the given amount and currency.
+func New(amount udecimal.Decimal, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Parse returns the money with the given amount and ISO 4217 currency code.
+//
+// Returns error if the amount is not a valid decimal or the currency is unknown ([ErrUnknownCurrency]).
+//
+// Example:
+//
+//	Parse("12.34", "USD") = 12.34 USD
+func Parse(amount, code string) (Money, error) {
+	c, err := ParseCurrency(code)
+	if err != nil {
+		return Money{}, err
+	}
+
+	d, err := udecimal.Parse(amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: d, Currency: c}, nil
+}
+
+// MustParse is similar to [Parse] but panics instead of returning error.
+func MustParse(amount, code string) Money {
+	m, err := Parse(amount, code)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// Add returns m + n.
+//
+// Returns [*CurrencyMismatchError] if m and n have different currencies.
+func (m Money) Add(n Money) (Money, error) {
+	if err := m.checkCurrency(n); err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: m.Amount.Add(n.Amount), Currency: m.Currency}, nil
+}
+
+// Sub returns m - n.
+//
+// Returns [*CurrencyMismatchError] if m and n have different currencies.
+func (m Money) Sub(n Money) (Money, error) {
+	if err := m.checkCurrency(n); err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: m.Amount.Sub(n.Amount), Currency: m.Currency}, nil
+}
+
+// Cmp compares m and n and returns:
+//
+//	-1 if m < n
+//	 0 if m == n
+//	+1 if m > n
+//
+// Returns [*CurrencyMismatchError] if m and n have different currencies.
+func (m Money) Cmp(n Money) (int, error) {
+	if err := m.checkCurrency(n); err != nil {
+		return 0, err
+	}
+
+	return m.Amount.Cmp(n.Amount), nil
+}
+
+// Equal reports whether m and n have the same currency and amount.
+// Unlike Cmp, amounts in different currencies are simply not equal.
+func (m Money) Equal(n Money) bool {
+	return m.Currency == n.Currency && m.Amount.Cmp(n.Amount) == 0
+}
+
+// Mul returns m * d in the same currency.
+// The amount is truncated to the default precision like [udecimal.Decimal.Mul], it's not rounded to the minor unit.
+func (m Money) Mul(d udecimal.Decimal) Money {
+	return Money{Amount: m.Amount.Mul(d), Currency: m.Currency}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{Amount: m.Amount.Neg(), Currency: m.Currency}
+}
+
+// Abs returns |m|.
+func (m Money) Abs() Money {
+	return Money{Amount: m.Amount.Abs(), Currency: m.Currency}
+}
+
+// Sign returns:
+//
+//	-1 if m < 0
+//	 0 if m == 0
+//	+1 if m > 0
+func (m Money) Sign() int {
+	return m.Amount.Sign()
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// RoundToMinorUnit rounds the amount to the number of digits of the currency's minor unit
+// using the given rounding mode.
+//
+// Panics if mode is not a valid [udecimal.RoundingMode].
+//
+// Example:
+//
+//	12.345 USD -> 12.35 USD (RoundHalfEven)
+//	1234.5 JPY -> 1234 JPY (RoundHalfEven)
+func (m Money) RoundToMinorUnit(mode udecimal.RoundingMode) Money {
+	return Money{Amount: m.Amount.Round(m.Currency.minorUnits, mode), Currency: m.Currency}
+}
+
+// Allocate splits m into parts proportional to ratios, each rounded to the currency's minor unit.
+// The parts always sum exactly to m, see [udecimal.Allocate].
+func (m Money) Allocate(ratios []udecimal.Decimal) ([]Money, error) {
+	parts, err := udecimal.Allocate(m.Amount, ratios, m.Currency.minorUnits)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.withAmounts(parts), nil
+}
+
+// Split splits m into n equal parts, each rounded to the currency's minor unit.
+// The parts always sum exactly to m, see [udecimal.Split].
+func (m Money) Split(n int) ([]Money, error) {
+	parts, err := udecimal.Split(m.Amount, n, m.Currency.minorUnits)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.withAmounts(parts), nil
+}
+
+// String returns the amount padded to the currency's minor unit followed by the currency code,
+// e.g. "12.30 USD". Digits beyond the minor unit are kept.
+func (m Money) String() string {
+	if m.Currency.IsZero() {
+		return m.Amount.String()
+	}
+
+	return m.Amount.StringFixed(m.Currency.minorUnits) + " " + m.Currency.code
+}
+
+func (m Money) checkCurrency(n Money) error {
+	if m.Currency != n.Currency {
+		return &CurrencyMismatchError{A: m.Currency, B: n.Currency}
+	}
+
+	return nil
+}
+
+func (m Money) withAmounts(amounts []udecimal.Decimal) []Money {
+	res := make([]Money, len(amounts))
+	for i, a := range amounts {
+		res[i] = Money{Amount: a, Currency: m.Currency}
+	}
+
+	return res
+}
@@ -0,0 +1,146 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markovichecha/udecimal"
+)
+
+func TestParse(t *testing.T) {
+	m, err := Parse("12.34", "usd")
+	require.NoError(t, err)
+	require.Equal(t, New(udecimal.MustParse("12.34"), USD), m)
+
+	_, err = Parse("12.34", "XYZ")
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+
+	_, err = Parse("12.3.4", "USD")
+	require.Error(t, err)
+
+	require.Panics(t, func() { _ = MustParse("1", "XYZ") })
+}
+
+func TestArithmetic(t *testing.T) {
+	testcases := []struct {
+		a, b         Money
+		add, sub     string
+		cmp          int
+		wantMismatch bool
+	}{
+		{MustParse("12.34", "USD"), MustParse("0.66", "USD"), "13.00 USD", "11.68 USD", 1, false},
+		{MustParse("-5", "EUR"), MustParse("5", "EUR"), "0.00 EUR", "-10.00 EUR", -1, false},
+		{MustParse("100", "JPY"), MustParse("100", "JPY"), "200 JPY", "0 JPY", 0, false},
+		{MustParse("1.005", "KWD"), MustParse("0.0001", "KWD"), "1.0051 KWD", "1.0049 KWD", 1, false},
+		{MustParse("100", "USD"), MustParse("100", "JPY"), "", "", 0, true},
+		{MustParse("0", "USD"), Money{}, "", "", 0, true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%s", tc.a, tc.b), func(t *testing.T) {
+			sum, errAdd := tc.a.Add(tc.b)
+			diff, errSub := tc.a.Sub(tc.b)
+			cmp, errCmp := tc.a.Cmp(tc.b)
+
+			if tc.wantMismatch {
+				for _, err := range []error{errAdd, errSub, errCmp} {
+					require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+					var mismatch *CurrencyMismatchError
+					require.True(t, errors.As(err, &mismatch))
+					require.Equal(t, tc.a.Currency, mismatch.A)
+					require.Equal(t, tc.b.Currency, mismatch.B)
+				}
+
+				require.False(t, tc.a.Equal(tc.b))
+				return
+			}
+
+			require.NoError(t, errAdd)
+			require.NoError(t, errSub)
+			require.NoError(t, errCmp)
+			require.Equal(t, tc.add, sum.String())
+			require.Equal(t, tc.sub, diff.String())
+			require.Equal(t, tc.cmp, cmp)
+			require.Equal(t, tc.cmp == 0, tc.a.Equal(tc.b))
+		})
+	}
+}
+
+func TestCurrencyMismatchError(t *testing.T) {
+	_, err := MustParse("1", "USD").Add(MustParse("1", "JPY"))
+	require.EqualError(t, err, "money: currency mismatch: USD and JPY")
+
+	_, err = MustParse("1", "USD").Add(Money{})
+	require.EqualError(t, err, "money: currency mismatch: USD and <none>")
+}
+
+func TestUnaryOps(t *testing.T) {
+	m := MustParse("-12.5", "USD")
+	require.Equal(t, "12.50 USD", m.Neg().String())
+	require.Equal(t, "12.50 USD", m.Abs().String())
+	require.Equal(t, -1, m.Sign())
+	require.False(t, m.IsZero())
+	require.True(t, Money{}.IsZero())
+	require.Equal(t, "-25.00 USD", m.Mul(udecimal.MustParse("2")).String())
+	require.Equal(t, "-1.5625 USD", m.Mul(udecimal.MustParse("0.125")).String())
+}
+
+func TestRoundToMinorUnit(t *testing.T) {
+	testcases := []struct {
+		amount, code string
+		mode         udecimal.RoundingMode
+		want         string
+	}{
+		{"12.345", "USD", udecimal.RoundHalfEven, "12.34 USD"},
+		{"12.345", "USD", udecimal.RoundHalfUp, "12.35 USD"},
+		{"-12.345", "USD", udecimal.RoundFloor, "-12.35 USD"},
+		{"12.3", "USD", udecimal.RoundHalfEven, "12.30 USD"},
+		{"1234.5", "JPY", udecimal.RoundHalfEven, "1234 JPY"},
+		{"1234.5", "JPY", udecimal.RoundHalfUp, "1235 JPY"},
+		{"1.23456", "KWD", udecimal.RoundHalfEven, "1.235 KWD"},
+		{"1.23456", "CLF", udecimal.RoundDown, "1.2345 CLF"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%s_%s", tc.amount, tc.code, tc.mode), func(t *testing.T) {
+			require.Equal(t, tc.want, MustParse(tc.amount, tc.code).RoundToMinorUnit(tc.mode).String())
+		})
+	}
+}
+
+func TestAllocateSplit(t *testing.T) {
+	parts, err := MustParse("100", "USD").Split(3)
+	require.NoError(t, err)
+	require.Equal(t, []string{"33.34 USD", "33.33 USD", "33.33 USD"}, moneyStrings(parts))
+
+	parts, err = MustParse("100", "JPY").Allocate([]udecimal.Decimal{udecimal.MustParse("1"), udecimal.MustParse("2")})
+	require.NoError(t, err)
+	require.Equal(t, []string{"33 JPY", "67 JPY"}, moneyStrings(parts))
+
+	_, err = MustParse("100.5", "JPY").Split(2)
+	require.Equal(t, udecimal.ErrAllocateAmountPrec, err)
+
+	_, err = MustParse("100", "USD").Allocate(nil)
+	require.Equal(t, udecimal.ErrAllocateNoRatios, err)
+}
+
+func TestString(t *testing.T) {
+	require.Equal(t, "12.30 USD", MustParse("12.3", "USD").String())
+	require.Equal(t, "12.345 USD", MustParse("12.345", "USD").String())
+	require.Equal(t, "5 JPY", MustParse("5", "JPY").String())
+	require.Equal(t, "0.000 BHD", MustParse("0", "BHD").String())
+	require.Equal(t, "0", Money{}.String())
+}
+
+func moneyStrings(ms []Money) []string {
+	ss := make([]string, len(ms))
+	for i, m := range ms {
+		ss[i] = m.String()
+	}
+
+	return ss
+}
@@ -0,0 +1,86 @@
+package udecimal
+
+import "math/big"
+
+// MulDiv returns d * e / f computed with a single division of the exact product.
+// Unlike d.Mul(e).Div(f), the product is not truncated to defaultPrec digits before the division.
+// If the result has more than defaultPrec fraction digits, it will be truncated to defaultPrec digits.
+//
+// Returns divide by zero error when f is zero.
+//
+// Examples:
+//
+//	MulDiv(0.0000000000000000005, 0.5, 0.25) = 0.000000000000000001
+//	MulDiv(100, 1, 3) = 33.3333333333333333333
+func (d Decimal) MulDiv(e, f Decimal) (Decimal, error) {
+	return d.mulDiv(e, f, defaultPrec, RoundDown)
+}
+
+// MulDivRound returns d * e / f rounded to prec digits after the decimal point using the given rounding mode.
+// The exact result is rounded directly, so there is no intermediate truncation.
+// If prec > defaultPrec, defaultPrec is used.
+//
+// Returns divide by zero error when f is zero.
+//
+// Examples:
+//
+//	MulDivRound(100, 2, 3, 2, RoundHalfEven) = 66.67
+//	MulDivRound(100, 2, 3, 2, RoundDown) = 66.66
+func (d Decimal) MulDivRound(e, f Decimal, prec uint8, mode RoundingMode) (Decimal, error) {
+	return d.mulDiv(e, f, min(prec, defaultPrec), mode)
+}
+
+func (d Decimal) mulDiv(e, f Decimal, prec uint8, mode RoundingMode) (Decimal, error) {
+	if f.coef.IsZero() {
+		return Decimal{}, ErrDivideByZero
+	}
+
+	neg := d.neg != e.neg != f.neg
+
+	// d * e / f = (d.coef * e.coef * 10^(prec + f.prec - d.prec - e.prec) / f.coef) / 10^prec
+	// Move 10^(d.prec + e.prec - prec - f.prec) to the divisor if the exponent is negative.
+	exp := int(prec) + int(f.prec) - int(d.prec) - int(e.prec)
+
+	if !d.coef.overflow() && !e.coef.overflow() && !f.coef.overflow() {
+		q, err := tryMulDivU128(d.coef.u128, e.coef.u128, f.coef.u128, exp, neg, mode)
+		if err == nil {
+			return newDecimal(neg, bintFromU128(q), prec), nil
+		}
+	}
+
+	// overflow, fallback to big.Int
+	num := d.coef.GetBig()
+	num.Mul(num, e.coef.GetBig())
+
+	den := f.coef.GetBig()
+	if exp >= 0 {
+		num.Mul(num, pow10BigInt(exp))
+	} else {
+		den.Mul(den, pow10BigInt(-exp))
+	}
+
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	return newDecimal(neg, bintFromBigInt(roundQuoBig(neg, q, r, den, mode)), prec), nil
+}
+
+func tryMulDivU128(a, b, c u128, exp int, neg bool, mode RoundingMode) (u128, error) {
+	var err error
+
+	num := a.MulToU256(b)
+	if exp > 0 {
+		num, err = num.mul128(pow10[exp])
+	} else if exp < 0 {
+		c, err = c.Mul(pow10[-exp])
+	}
+
+	if err != nil {
+		return u128{}, err
+	}
+
+	q, r, err := num.fastQuo(c)
+	if err != nil {
+		return u128{}, err
+	}
+
+	return roundQuoU128(neg, q, r, c, mode)
+}
@@ -0,0 +1,106 @@
+package udecimal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMulDiv(t *testing.T) {
+	testcases := []struct {
+		a, b, c string
+		want    string
+		wantErr error
+	}{
+		{"1", "1", "0", "", ErrDivideByZero},
+		{"0", "123", "7", "0", nil},
+		{"1.5", "1.5", "0.7", "3.2142857142857142857", nil},
+		{"-10", "3", "7", "-4.2857142857142857142", nil},
+		{"-10", "-3", "-7", "-4.2857142857142857142", nil},
+		{"0.0000000000000000005", "0.5", "0.25", "0.000000000000000001", nil},
+		{"123456789012345678901234567890", "98765432109876543210", "12345678901234567890", "987654321098765432109876543210", nil},
+		{maxU128Str, maxU128Str, maxU128Str, maxU128Str, nil},
+		{"1234567890123456789012345678901234567890", "3", "7", "529100524338624338148148148100529100524.2857142857142857142", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s*%s/%s", tc.a, tc.b, tc.c), func(t *testing.T) {
+			a := MustParse(tc.a)
+			b := MustParse(tc.b)
+			c := MustParse(tc.c)
+
+			q, err := a.MulDiv(b, c)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, q.String())
+		})
+	}
+}
+
+func TestMulDivRound(t *testing.T) {
+	testcases := []struct {
+		a, b, c string
+		prec    uint8
+		mode    RoundingMode
+		want    string
+		wantErr error
+	}{
+		{"1", "1", "0", 2, RoundHalfEven, "", ErrDivideByZero},
+		{"100", "2", "3", 2, RoundHalfEven, "66.67", nil},
+		{"100", "2", "3", 2, RoundDown, "66.66", nil},
+		{"-100", "2", "3", 2, RoundFloor, "-66.67", nil},
+		{"-100", "2", "3", 2, RoundCeiling, "-66.66", nil},
+		{"1", "1", "8", 2, RoundHalfEven, "0.12", nil},
+		{"1", "1", "8", 2, RoundHalfUp, "0.13", nil},
+		{"-1", "-1", "8", 2, RoundHalfDown, "0.12", nil},
+		{"1", "1", "3", 25, RoundUp, "0.3333333333333333334", nil},
+		{"1000.25", "0.05", "365", 10, RoundHalfEven, "0.1370205479", nil},
+		{"0.0000000000000000001", "0.0000000000000000001", "0.0000000000000000003", 19, RoundHalfEven, "0", nil},
+		{"12345678901234567890.123", "98765432109876543210.5", "3", 4, RoundHalfUp, "406442103790072650751928262903798541886.6305", nil},
+		{"1234567890123456789012345678901234567890", "3", "7", 2, RoundHalfEven, "529100524338624338148148148100529100524.29", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s*%s/%s_%d_%s", tc.a, tc.b, tc.c, tc.prec, tc.mode), func(t *testing.T) {
+			a := MustParse(tc.a)
+			b := MustParse(tc.b)
+			c := MustParse(tc.c)
+
+			q, err := a.MulDivRound(b, c, tc.prec, tc.mode)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, q.String())
+		})
+	}
+}
+
+func TestMulDivConsistency(t *testing.T) {
+	// when the product is exact, MulDivRound must match Mul followed by DivRound
+	inputs := []string{"1", "-1", "0.5", "3", "7.25", "-123.456", "1234567890.123456789", "0.000001"}
+
+	for _, a := range inputs {
+		for _, b := range inputs {
+			for _, c := range inputs {
+				for _, mode := range allRoundingModes {
+					da, db, dc := MustParse(a), MustParse(b), MustParse(c)
+
+					want, err := da.Mul(db).DivRound(dc, 6, mode)
+					require.NoError(t, err)
+
+					got, err := da.MulDivRound(db, dc, 6, mode)
+					require.NoError(t, err)
+					require.Equal(t, want, got, "%s*%s/%s %s", a, b, c, mode)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package udecimal
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrWhitespace is returned by [ParseWithOptions] when the string starts or ends with whitespace
+	// and ParseOptions.TrimSpace is false
+	ErrWhitespace = fmt.Errorf("leading or trailing whitespace is not allowed")
+
+	// ErrUnderscore is returned by [ParseWithOptions] when the string has an underscore and
+	// ParseOptions.AllowUnderscores is false, or when an underscore is not between two digits
+	ErrUnderscore = fmt.Errorf("underscores are only allowed between digits with AllowUnderscores")
+
+	// ErrPlusSign is returned by [ParseWithOptions] when the string starts with '+' and ParseOptions.RejectPlus is true
+	ErrPlusSign = fmt.Errorf("leading plus sign is not allowed")
+
+	// ErrLeadingZeros is returned by [ParseWithOptions] when the integer part has leading zeros, e.g. 007,
+	// and ParseOptions.RejectLeadingZeros is true
+	ErrLeadingZeros = fmt.Errorf("leading zeros are not allowed")
+
+	// ErrScaleMismatch is returned by [ParseWithOptions] when the number of digits after the decimal point
+	// is not ParseOptions.Scale and ParseOptions.ScaleRule is [ScaleExact]
+	ErrScaleMismatch = fmt.Errorf("number of digits after the decimal point doesn't match the scale")
+
+	// ErrScaleExceeded is returned by [ParseWithOptions] when the number of digits after the decimal point
+	// is greater than ParseOptions.Scale and ParseOptions.ScaleRule is [ScaleAtMost]
+	ErrScaleExceeded = fmt.Errorf("number of digits after the decimal point exceeds the scale")
+
+	// ErrMaxLength is returned by [ParseWithOptions] when the string is longer than ParseOptions.MaxLength
+	ErrMaxLength = fmt.Errorf("string input exceeds maximum length")
+)
+
+// ScaleRule is how [ParseWithOptions] checks the number of digits after the decimal point against ParseOptions.Scale
+type ScaleRule uint8
+
+const (
+	// ScaleAny accepts any number of digits after the decimal point, up to the default precision
+	ScaleAny ScaleRule = iota
+
+	// ScaleExact requires exactly Scale digits after the decimal point, e.g. 2 accepts 1.50 but not 1.5 or 1
+	ScaleExact
+
+	// ScaleAtMost accepts at most Scale digits after the decimal point
+	ScaleAtMost
+)
+
+// ParseOptions configures [ParseWithOptions]. The zero value accepts the same strings as [Parse]
+// with scientific notation disabled.
+type ParseOptions struct {
+	// AllowUnderscores accepts underscores between digits, like Go number literals, e.g. 1_000.50
+	AllowUnderscores bool
+
+	// AllowExponent accepts scientific notation, e.g. 1.5e3, see [Context.WithParseExponent]
+	AllowExponent bool
+
+	// TrimSpace removes leading and trailing whitespace before parsing
+	TrimSpace bool
+
+	// RejectPlus rejects a leading '+'
+	RejectPlus bool
+
+	// RejectLeadingZeros rejects leading zeros in the integer part, e.g. 01.5. A single 0 is accepted, e.g. 0.5
+	RejectLeadingZeros bool
+
+	// Scale is the number of digits after the decimal point checked with ScaleRule.
+	// With scientific notation, it's the number of digits of the number without exponent.
+	Scale uint8
+
+	// ScaleRule is how the digits after the decimal point are checked against Scale
+	ScaleRule ScaleRule
+
+	// MaxLength is the maximum length of the string after trimming, including underscores.
+	// 0 means the default limit of 200 characters (see [ErrMaxStrLen]). It can be at most 1<<20.
+	MaxLength int
+}
+
+// ParseWithOptions parses a number in string to a decimal, like [Parse] with the rules of opts.
+// The precision and the parse mode are the package defaults.
+//
+// Returns error if:
+//  1. empty/invalid string ([ErrEmptyString], [ErrInvalidFormat])
+//  2. leading or trailing whitespace without TrimSpace ([ErrWhitespace])
+//  3. the string is longer than MaxLength ([ErrMaxLength]) or 200 characters by default ([ErrMaxStrLen])
+//  4. an underscore without AllowUnderscores or not between two digits ([ErrUnderscore])
+//  5. a leading '+' with RejectPlus ([ErrPlusSign])
+//  6. leading zeros with RejectLeadingZeros ([ErrLeadingZeros])
+//  7. the number has more than 19 digits after the decimal point ([ErrPrecOutOfRange])
+//  8. the digits after the decimal point don't satisfy ScaleRule ([ErrScaleMismatch], [ErrScaleExceeded])
+//
+// Example:
+//
+//	ParseWithOptions(" 1_000.50 ", ParseOptions{AllowUnderscores: true, TrimSpace: true}) -> 1000.50
+//	ParseWithOptions("1.5", ParseOptions{Scale: 2, ScaleRule: ScaleExact}) -> ErrScaleMismatch
+func ParseWithOptions(s string, opts ParseOptions) (Decimal, error) {
+	str, err := opts.trim(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	if str == "" {
+		return Decimal{}, ErrEmptyString
+	}
+
+	// the limit counts the underscores, so it's checked before they are removed
+	maxLen := maxStrLen
+	if opts.MaxLength > 0 {
+		maxLen = min(opts.MaxLength, maxParseExponent)
+
+		if len(str) > maxLen {
+			return Decimal{}, fmt.Errorf("%w %d", ErrMaxLength, maxLen)
+		}
+	} else if len(str) > maxLen {
+		return Decimal{}, ErrMaxStrLen
+	}
+
+	b := unsafeStringToBytes(str)
+
+	if opts.RejectPlus && b[0] == '+' {
+		return Decimal{}, fmt.Errorf("%w: can't parse '%s'", ErrPlusSign, b)
+	}
+
+	b, err = opts.removeUnderscores(b)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	if opts.RejectLeadingZeros && hasLeadingZeros(b) {
+		return Decimal{}, fmt.Errorf("%w: can't parse '%s'", ErrLeadingZeros, str)
+	}
+
+	neg, coef, prec, err := parseBintExp(b, defaultPrec, defaultParseMode, opts.AllowExponent, maxLen)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	switch {
+	case opts.ScaleRule == ScaleExact && prec != opts.Scale:
+		return Decimal{}, fmt.Errorf("%w: '%s' has %d digits, want %d", ErrScaleMismatch, str, prec, opts.Scale)
+	case opts.ScaleRule == ScaleAtMost && prec > opts.Scale:
+		return Decimal{}, fmt.Errorf("%w: '%s' has %d digits, want at most %d", ErrScaleExceeded, str, prec, opts.Scale)
+	}
+
+	return newDecimal(neg, coef, prec), nil
+}
+
+// MustParseWithOptions is similar to [ParseWithOptions] but panics instead of returning error.
+func MustParseWithOptions(s string, opts ParseOptions) Decimal {
+	d, err := ParseWithOptions(s, opts)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// trim removes the surrounding whitespace of s with TrimSpace, otherwise it rejects it
+func (opts ParseOptions) trim(s string) (string, error) {
+	first, _ := utf8.DecodeRuneInString(s)
+	last, _ := utf8.DecodeLastRuneInString(s)
+
+	if s == "" || (!unicode.IsSpace(first) && !unicode.IsSpace(last)) {
+		return s, nil
+	}
+
+	if !opts.TrimSpace {
+		return "", fmt.Errorf("%w: can't parse '%s'", ErrWhitespace, s)
+	}
+
+	return strings.TrimSpace(s), nil
+}
+
+// removeUnderscores returns b without underscores, which must be between two digits.
+// b is returned as is when it has no underscore.
+func (opts ParseOptions) removeUnderscores(b []byte) ([]byte, error) {
+	n := 0
+	for _, c := range b {
+		if c == '_' {
+			n++
+		}
+	}
+
+	if n == 0 {
+		return b, nil
+	}
+
+	if !opts.AllowUnderscores {
+		return nil, fmt.Errorf("%w: can't parse '%s'", ErrUnderscore, b)
+	}
+
+	out := make([]byte, 0, len(b)-n)
+	for i, c := range b {
+		if c != '_' {
+			out = append(out, c)
+			continue
+		}
+
+		if i == 0 || i == len(b)-1 || !isDigit(b[i-1]) || !isDigit(b[i+1]) {
+			return nil, fmt.Errorf("%w: can't parse '%s'", ErrUnderscore, b)
+		}
+	}
+
+	return out, nil
+}
+
+// hasLeadingZeros reports whether the integer part of b has more than one digit and starts with 0
+func hasLeadingZeros(b []byte) bool {
+	if len(b) > 0 && (b[0] == '-' || b[0] == '+') {
+		b = b[1:]
+	}
+
+	return len(b) > 1 && b[0] == '0' && isDigit(b[1])
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,148 @@
+package udecimal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWithOptions(t *testing.T) {
+	testcases := []struct {
+		s       string
+		opts    ParseOptions
+		want    string
+		prec    uint8
+		wantErr error
+	}{
+		// zero value is the same as Parse
+		{"123.456", ParseOptions{}, "123.456", 3, nil},
+		{"+1", ParseOptions{}, "1", 0, nil},
+		{"007", ParseOptions{}, "7", 0, nil},
+		{"1.50", ParseOptions{}, "1.5", 2, nil},
+		{"", ParseOptions{}, "", 0, ErrEmptyString},
+		{"1.2.3", ParseOptions{}, "", 0, ErrInvalidFormat},
+		{"0.12345678901234567890", ParseOptions{}, "", 0, ErrPrecOutOfRange},
+		{"1e3", ParseOptions{}, "", 0, ErrInvalidFormat},
+
+		// whitespace
+		{" 1", ParseOptions{}, "", 0, ErrWhitespace},
+		{"1\n", ParseOptions{}, "", 0, ErrWhitespace},
+		{" 1", ParseOptions{}, "", 0, ErrWhitespace},
+		{" 1.5 ", ParseOptions{TrimSpace: true}, "1.5", 1, nil},
+		{"\t-1.5\r\n", ParseOptions{TrimSpace: true}, "-1.5", 1, nil},
+		{" 1", ParseOptions{TrimSpace: true}, "1", 0, nil},
+		{"1 000", ParseOptions{TrimSpace: true}, "", 0, ErrInvalidFormat},
+		{"   ", ParseOptions{TrimSpace: true}, "", 0, ErrEmptyString},
+
+		// underscores
+		{"1_000", ParseOptions{}, "", 0, ErrUnderscore},
+		{"1_000.50", ParseOptions{AllowUnderscores: true}, "1000.5", 2, nil},
+		{"-1_000_000.000_001", ParseOptions{AllowUnderscores: true}, "-1000000.000001", 6, nil},
+		{"1_0e1_0", ParseOptions{AllowUnderscores: true, AllowExponent: true}, "100000000000", 0, nil},
+		{"_1", ParseOptions{AllowUnderscores: true}, "", 0, ErrUnderscore},
+		{"1_", ParseOptions{AllowUnderscores: true}, "", 0, ErrUnderscore},
+		{"1__0", ParseOptions{AllowUnderscores: true}, "", 0, ErrUnderscore},
+		{"1_.5", ParseOptions{AllowUnderscores: true}, "", 0, ErrUnderscore},
+		{"1._5", ParseOptions{AllowUnderscores: true}, "", 0, ErrUnderscore},
+		{"-_1", ParseOptions{AllowUnderscores: true}, "", 0, ErrUnderscore},
+		{"1_e5", ParseOptions{AllowUnderscores: true, AllowExponent: true}, "", 0, ErrUnderscore},
+
+		// plus sign
+		{"+1", ParseOptions{RejectPlus: true}, "", 0, ErrPlusSign},
+		{"+1_000", ParseOptions{RejectPlus: true}, "", 0, ErrPlusSign},
+		{"-1", ParseOptions{RejectPlus: true}, "-1", 0, nil},
+
+		// leading zeros
+		{"007", ParseOptions{RejectLeadingZeros: true}, "", 0, ErrLeadingZeros},
+		{"-01.5", ParseOptions{RejectLeadingZeros: true}, "", 0, ErrLeadingZeros},
+		{"+00", ParseOptions{RejectLeadingZeros: true}, "", 0, ErrLeadingZeros},
+		{"0_1", ParseOptions{RejectLeadingZeros: true, AllowUnderscores: true}, "", 0, ErrLeadingZeros},
+		{"01e2", ParseOptions{RejectLeadingZeros: true, AllowExponent: true}, "", 0, ErrLeadingZeros},
+		{"0", ParseOptions{RejectLeadingZeros: true}, "0", 0, nil},
+		{"-0.05", ParseOptions{RejectLeadingZeros: true}, "-0.05", 2, nil},
+		{"10.0", ParseOptions{RejectLeadingZeros: true}, "10", 1, nil},
+		{"0e5", ParseOptions{RejectLeadingZeros: true, AllowExponent: true}, "0", 0, nil},
+
+		// scale
+		{"1.50", ParseOptions{Scale: 2, ScaleRule: ScaleExact}, "1.5", 2, nil},
+		{"1.5", ParseOptions{Scale: 2, ScaleRule: ScaleExact}, "", 0, ErrScaleMismatch},
+		{"1.500", ParseOptions{Scale: 2, ScaleRule: ScaleExact}, "", 0, ErrScaleMismatch},
+		{"1", ParseOptions{Scale: 2, ScaleRule: ScaleExact}, "", 0, ErrScaleMismatch},
+		{"1", ParseOptions{ScaleRule: ScaleExact}, "1", 0, nil},
+		{"1.5e3", ParseOptions{ScaleRule: ScaleExact, AllowExponent: true}, "1500", 0, nil},
+		{"1.5", ParseOptions{Scale: 2, ScaleRule: ScaleAtMost}, "1.5", 1, nil},
+		{"1", ParseOptions{Scale: 2, ScaleRule: ScaleAtMost}, "1", 0, nil},
+		{"1.505", ParseOptions{Scale: 2, ScaleRule: ScaleAtMost}, "", 0, ErrScaleExceeded},
+		{"1.5", ParseOptions{ScaleRule: ScaleAtMost}, "", 0, ErrScaleExceeded},
+		{"1.505", ParseOptions{Scale: 2}, "1.505", 3, nil},
+
+		// length
+		{"1234", ParseOptions{MaxLength: 4}, "1234", 0, nil},
+		{"12345", ParseOptions{MaxLength: 4}, "", 0, ErrMaxLength},
+		{"1_234", ParseOptions{MaxLength: 4, AllowUnderscores: true}, "", 0, ErrMaxLength},
+		{"1_234", ParseOptions{MaxLength: 5, AllowUnderscores: true}, "1234", 0, nil},
+		{"11" + strings.Repeat("_1", 99), ParseOptions{AllowUnderscores: true}, strings.Repeat("1", 101), 0, nil},
+		{"1" + strings.Repeat("_1", 100), ParseOptions{AllowUnderscores: true}, "", 0, ErrMaxStrLen},
+		{"  1234  ", ParseOptions{MaxLength: 4, TrimSpace: true}, "1234", 0, nil},
+		{"1e10", ParseOptions{MaxLength: 8, AllowExponent: true}, "", 0, ErrExponentOutOfRange},
+		{strings.Repeat("1", 201), ParseOptions{}, "", 0, ErrMaxStrLen},
+		{strings.Repeat("1", 300), ParseOptions{MaxLength: 300}, strings.Repeat("1", 300), 0, nil},
+		{strings.Repeat("1", 300), ParseOptions{MaxLength: 299}, "", 0, ErrMaxLength},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.s, func(t *testing.T) {
+			d, err := ParseWithOptions(tc.s, tc.opts)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, d.String())
+			require.Equal(t, tc.prec, d.PrecUint())
+		})
+	}
+}
+
+func TestParseWithOptionsErrorsAreDistinct(t *testing.T) {
+	errs := []error{
+		ErrWhitespace, ErrUnderscore, ErrPlusSign, ErrLeadingZeros,
+		ErrScaleMismatch, ErrScaleExceeded, ErrMaxLength, ErrMaxStrLen, ErrInvalidFormat,
+	}
+
+	for i, a := range errs {
+		for j, b := range errs {
+			if i != j {
+				require.NotErrorIs(t, a, b)
+			}
+		}
+	}
+}
+
+func TestParseWithOptionsConsistency(t *testing.T) {
+	inputs := []string{
+		"0", "-0", "1", "-1", "1.5", "-123.456", "+42", "0.0000000000000000001",
+		"123456789012345678901234567890123456789", "-1234567890123456789.1234567890123456789",
+		"", ".5", "5.", "-", "+", "1.2.3", "abc", "1e3", "0.12345678901234567890",
+	}
+
+	for _, s := range inputs {
+		want, wantErr := Parse(s)
+		got, err := ParseWithOptions(s, ParseOptions{})
+
+		if wantErr != nil {
+			require.Error(t, err, s)
+			require.Equal(t, wantErr.Error(), err.Error(), s)
+
+			continue
+		}
+
+		require.NoError(t, err, s)
+		require.Equal(t, want, got, s)
+	}
+
+	require.Equal(t, MustParse("1000.5"), MustParseWithOptions("1_000.5", ParseOptions{AllowUnderscores: true}))
+	require.Panics(t, func() { MustParseWithOptions("1_000.5", ParseOptions{}) })
+}
@@ -0,0 +1,647 @@
+package udecimal
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+// RoundingMode specifies how a decimal is rounded when digits are discarded.
+// The zero value is [RoundHalfEven].
+type RoundingMode uint8
+
+const (
+	// RoundHalfEven rounds to the nearest neighbor, ties go to the even neighbor (banker's rounding).
+	//	1.25 -> 1.2, 1.35 -> 1.4, -1.25 -> -1.2
+	RoundHalfEven RoundingMode = iota
+
+	// RoundHalfUp rounds to the nearest neighbor, ties go away from zero.
+	//	1.25 -> 1.3, -1.25 -> -1.3
+	RoundHalfUp
+
+	// RoundHalfDown rounds to the nearest neighbor, ties go toward zero.
+	//	1.25 -> 1.2, -1.25 -> -1.2
+	RoundHalfDown
+
+	// RoundUp rounds away from zero.
+	//	1.21 -> 1.3, -1.21 -> -1.3
+	RoundUp
+
+	// RoundDown rounds toward zero (truncation).
+	//	1.29 -> 1.2, -1.29 -> -1.2
+	RoundDown
+
+	// RoundCeiling rounds toward positive infinity.
+	//	1.21 -> 1.3, -1.29 -> -1.2
+	RoundCeiling
+
+	// RoundFloor rounds toward negative infinity.
+	//	1.29 -> 1.2, -1.21 -> -1.3
+	RoundFloor
+
+	// RoundHalfOdd rounds to the nearest neighbor, ties go to the odd neighbor.
+	//	1.25 -> 1.3, 1.35 -> 1.3, -1.25 -> -1.3
+	RoundHalfOdd
+
+	// Round05Up rounds away from zero if the last digit after rounding toward zero is 0 or 5,
+	// otherwise rounds toward zero.
+	//	1.01 -> 1.1, 1.51 -> 1.6, 1.29 -> 1.2
+	Round05Up
+)
+
+var roundingModeNames = [...]string{
+	RoundHalfEven: "half_even",
+	RoundHalfUp:   "half_up",
+	RoundHalfDown: "half_down",
+	RoundUp:       "up",
+	RoundDown:     "down",
+	RoundCeiling:  "ceiling",
+	RoundFloor:    "floor",
+	RoundHalfOdd:  "half_odd",
+	Round05Up:     "05up",
+}
+
+// ErrInvalidRoundingMode is returned when parsing an unknown rounding mode
+var ErrInvalidRoundingMode = fmt.Errorf("invalid rounding mode")
+
+// ErrInvalidIncrement is returned by RoundToIncrement when the increment is not positive
+var ErrInvalidIncrement = fmt.Errorf("can't round to a non-positive increment")
+
+// String returns the name of the rounding mode, e.g. "half_even"
+func (m RoundingMode) String() string {
+	if int(m) < len(roundingModeNames) {
+		return roundingModeNames[m]
+	}
+
+	return fmt.Sprintf("RoundingMode(%d)", m)
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (m RoundingMode) MarshalText() ([]byte, error) {
+	if !m.valid() {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidRoundingMode, m)
+	}
+
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// The name is case-insensitive and '-' can be used instead of '_', e.g. "HALF_EVEN" or "half-even".
+func (m *RoundingMode) UnmarshalText(text []byte) error {
+	name := strings.ReplaceAll(strings.ToLower(string(text)), "-", "_")
+	for i, s := range roundingModeNames {
+		if s == name {
+			//nolint:gosec // i < len(roundingModeNames), so it's safe to convert to uint8
+			*m = RoundingMode(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q", ErrInvalidRoundingMode, text)
+}
+
+func (m RoundingMode) valid() bool {
+	return m <= Round05Up
+}
+
+// roundUp reports whether the magnitude of a truncated value should be incremented by one unit.
+// The discarded part must not be zero.
+//
+//   - neg: the sign of the value
+//   - digit: the last digit of the truncated magnitude
+//   - cmpHalf: the comparison between the discarded part and half of the unit (-1, 0 or 1)
+func (m RoundingMode) roundUp(neg bool, digit uint64, cmpHalf int) bool {
+	switch m {
+	case RoundHalfEven:
+		return cmpHalf > 0 || (cmpHalf == 0 && digit%2 == 1)
+	case RoundHalfUp:
+		return cmpHalf >= 0
+	case RoundHalfDown:
+		return cmpHalf > 0
+	case RoundUp:
+		return true
+	case RoundDown:
+		return false
+	case RoundCeiling:
+		return !neg
+	case RoundFloor:
+		return neg
+	case RoundHalfOdd:
+		return cmpHalf > 0 || (cmpHalf == 0 && digit%2 == 0)
+	case Round05Up:
+		return digit == 0 || digit == 5
+	default:
+		panic(fmt.Sprintf("can't round: invalid rounding mode %d", m))
+	}
+}
+
+// Round rounds the decimal to the specified prec using the given rounding mode.
+// If prec >= d.Prec(), d is returned unchanged.
+//
+// Panics if mode is not a valid [RoundingMode].
+//
+// Examples:
+//
+//	Round(1.25, 1, RoundHalfEven) = 1.2
+//	Round(1.25, 1, RoundHalfUp) = 1.3
+//	Round(-1.21, 1, RoundFloor) = -1.3
+//	Round(-1.29, 1, RoundCeiling) = -1.2
+func (d Decimal) Round(prec uint8, mode RoundingMode) Decimal {
+	if prec >= d.prec {
+		return d
+	}
+
+	coef := roundCoef(d.neg, d.coef, d.prec-prec, mode)
+	return newDecimal(d.neg, coef, prec)
+}
+
+// FloorPrec returns the largest decimal with prec digits after the decimal point that is less than or equal to d.
+//
+// Examples:
+//
+//	FloorPrec(1.129, 2) = 1.12
+//	FloorPrec(-1.121, 2) = -1.13
+func (d Decimal) FloorPrec(prec uint8) Decimal {
+	return d.Round(prec, RoundFloor)
+}
+
+// CeilPrec returns the smallest decimal with prec digits after the decimal point that is greater than or equal to d.
+//
+// Examples:
+//
+//	CeilPrec(1.121, 2) = 1.13
+//	CeilPrec(-1.129, 2) = -1.12
+func (d Decimal) CeilPrec(prec uint8) Decimal {
+	return d.Round(prec, RoundCeiling)
+}
+
+// RoundToIncrement rounds the decimal to a multiple of step using the given rounding mode.
+// It's useful for tick sizes (e.g. 0.25) and cash rounding (e.g. 0.05).
+// The result has the same precision as step.
+//
+// Returns [ErrInvalidIncrement] if step is not positive.
+// Panics if mode is not a valid [RoundingMode].
+//
+// Examples:
+//
+//	RoundToIncrement(1.37, 0.25, RoundHalfEven) = 1.25
+//	RoundToIncrement(1.375, 0.25, RoundHalfEven) = 1.5
+//	RoundToIncrement(1.025, 0.05, RoundHalfUp) = 1.05
+//	RoundToIncrement(-1.01, 0.05, RoundFloor) = -1.05
+func (d Decimal) RoundToIncrement(step Decimal, mode RoundingMode) (Decimal, error) {
+	if step.neg || step.coef.IsZero() {
+		return Decimal{}, ErrInvalidIncrement
+	}
+
+	q, r, err := d.QuoRem(step)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	if !r.IsZero() {
+		// compare |r| with step/2 using 2*|r| with step
+		cmpHalf := r.Add(r).Abs().Cmp(step)
+
+		var digit uint64
+		if !q.coef.overflow() {
+			_, digit = q.coef.u128.QuoRem64(10)
+		} else {
+			digit = new(big.Int).Rem(q.coef.GetBig(), bigTen).Uint64()
+		}
+
+		if mode.roundUp(d.neg, digit, cmpHalf) {
+			if d.neg {
+				q = q.Sub(One)
+			} else {
+				q = q.Add(One)
+			}
+		}
+	}
+
+	return q.Mul(step), nil
+}
+
+// IsMultipleOf reports whether d is an integer multiple of step.
+// Zero is the only multiple of zero.
+//
+// Examples:
+//
+//	IsMultipleOf(1.75, 0.25) = true
+//	IsMultipleOf(1.03, 0.05) = false
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	if step.coef.IsZero() {
+		return d.coef.IsZero()
+	}
+
+	r, err := d.Mod(step)
+	return err == nil && r.IsZero()
+}
+
+// RoundSig rounds the decimal to n significant digits using the given rounding mode.
+// If d has at most n significant digits, d is returned unchanged.
+// If n is 0, zero is returned.
+//
+// Panics if mode is not a valid [RoundingMode].
+//
+// Examples:
+//
+//	RoundSig(0.000123456, 3, RoundHalfEven) = 0.000123
+//	RoundSig(123456, 3, RoundHalfEven) = 123000
+//	RoundSig(-987.65, 2, RoundFloor) = -990
+//	RoundSig(9.99, 2, RoundHalfUp) = 10
+func (d Decimal) RoundSig(n uint8, mode RoundingMode) Decimal {
+	if n == 0 {
+		return Zero
+	}
+
+	digits := numDigits(d.coef)
+	if digits <= int(n) {
+		return d
+	}
+
+	// number of digits to discard
+	k := digits - int(n)
+	if k <= int(d.prec) {
+		//nolint:gosec // k <= d.prec, so it's safe to convert to uint8
+		return d.Round(d.prec-uint8(k), mode)
+	}
+
+	// discard all fraction digits and some digits of the integer part,
+	// then scale the result back by 10^(k - d.prec)
+	var coef bint
+	if k <= 38 {
+		//nolint:gosec // k <= 38, so it's safe to convert to uint8
+		coef = roundCoef(d.neg, d.coef, uint8(k), mode)
+	} else {
+		factor := pow10BigInt(k)
+		q, r := new(big.Int).QuoRem(d.coef.GetBig(), factor, new(big.Int))
+		coef = bintFromBigInt(roundQuoBig(d.neg, q, r, factor, mode))
+	}
+
+	scale := k - int(d.prec)
+	if scale <= 38 {
+		coef = coef.Mul(bintFromU128(pow10[scale]))
+	} else {
+		coef = bintFromBigInt(new(big.Int).Mul(coef.GetBig(), pow10BigInt(scale)))
+	}
+
+	return newDecimal(d.neg, coef, 0)
+}
+
+// NumSignificantDigits returns the number of significant digits of the decimal,
+// i.e. the number of digits from the first to the last non-zero digit.
+// Zero has no significant digits.
+//
+// Examples:
+//
+//	NumSignificantDigits(0.000123) = 3
+//	NumSignificantDigits(123000) = 3
+//	NumSignificantDigits(1.20) = 2
+//	NumSignificantDigits(100.001) = 6
+func (d Decimal) NumSignificantDigits() int {
+	if d.coef.IsZero() {
+		return 0
+	}
+
+	if !d.coef.overflow() {
+		coef := d.coef.u128
+		zeros := 0
+
+		for {
+			q, r := coef.QuoRem64(10)
+			if r != 0 {
+				break
+			}
+
+			coef = q
+			zeros++
+		}
+
+		return numDigits(d.coef) - zeros
+	}
+
+	s := d.coef.bigInt.Text(10)
+	return len(strings.TrimRight(s, "0"))
+}
+
+// numDigits returns the number of decimal digits of coef, 0 has no digits
+func numDigits(coef bint) int {
+	if coef.overflow() {
+		if coef.bigInt.Sign() == 0 {
+			return 0
+		}
+
+		return len(coef.bigInt.Text(10))
+	}
+
+	// pow10[i] <= coef < pow10[i+1] means coef has i+1 digits
+	n := sort.Search(len(pow10), func(i int) bool {
+		return coef.u128.Cmp(pow10[i]) < 0
+	})
+
+	return n
+}
+
+// roundCoef returns coef / 10^n rounded with the given mode, where 0 < n <= 38
+// and neg is the sign of the value.
+func roundCoef(neg bool, coef bint, n uint8, mode RoundingMode) bint {
+	factor := pow10[n]
+
+	if !coef.overflow() {
+		q, r, err := coef.u128.QuoRem(factor)
+		if err == nil {
+			q, err = roundQuoU128(neg, q, r, factor, mode)
+			if err == nil {
+				return bintFromU128(q)
+			}
+		}
+	}
+
+	// overflow, fallback to big.Int
+	q, r := new(big.Int).QuoRem(coef.GetBig(), factor.ToBigInt(), new(big.Int))
+	return bintFromBigInt(roundQuoBig(neg, q, r, factor.ToBigInt(), mode))
+}
+
+// roundQuoU128 rounds the quotient q of a division with the remainder r and the divisor v
+func roundQuoU128(neg bool, q, r, v u128, mode RoundingMode) (u128, error) {
+	if r.IsZero() {
+		return q, nil
+	}
+
+	// compare r with v/2 using 2*r with v to avoid precision loss when v is odd
+	var cmpHalf int
+	if r.hi>>63 != 0 {
+		// 2*r overflows, which means 2*r > v
+		cmpHalf = 1
+	} else {
+		cmpHalf = r.Lsh(1).Cmp(v)
+	}
+
+	_, digit := q.QuoRem64(10)
+	if mode.roundUp(neg, digit, cmpHalf) {
+		return q.Add64(1)
+	}
+
+	return q, nil
+}
+
+// roundQuoBig rounds the quotient q of a division with the remainder r and the divisor v.
+// q is modified in place.
+func roundQuoBig(neg bool, q, r, v *big.Int, mode RoundingMode) *big.Int {
+	if r.Sign() == 0 {
+		return q
+	}
+
+	cmpHalf := new(big.Int).Lsh(r, 1).Cmp(v)
+	digit := new(big.Int).Rem(q, bigTen).Uint64()
+	if mode.roundUp(neg, digit, cmpHalf) {
+		q.Add(q, bigOne)
+	}
+
+	return q
+}
+
+// MulRound returns d * e rounded to prec digits after the decimal point using the given rounding mode.
+// Unlike Mul, the exact product is rounded directly instead of being truncated to defaultPrec first.
+// If prec > defaultPrec, defaultPrec is used.
+//
+// Examples:
+//
+//	MulRound(1.15, 0.5, 2, RoundHalfEven) = 0.58 (exact product is 0.575)
+//	MulRound(1.15, 0.5, 2, RoundDown) = 0.57
+func (d Decimal) MulRound(e Decimal, prec uint8, mode RoundingMode) Decimal {
+	return d.mulRound(e, min(prec, defaultPrec), mode)
+}
+
+// mulRound is MulRound with prec <= maxPrec, which doesn't depend on defaultPrec
+func (d Decimal) mulRound(e Decimal, prec uint8, mode RoundingMode) Decimal {
+	neg := d.neg != e.neg
+	exactPrec := d.prec + e.prec
+
+	if !d.coef.overflow() && !e.coef.overflow() {
+		rcoef := d.coef.u128.MulToU256(e.coef.u128)
+		if exactPrec <= prec {
+			if rcoef.carry.IsZero() {
+				return newDecimal(neg, bintFromU128(u128{hi: rcoef.hi, lo: rcoef.lo}), exactPrec)
+			}
+		} else {
+			factor := pow10[exactPrec-prec]
+			q, r, err := rcoef.fastQuo(factor)
+			if err == nil {
+				q, err = roundQuoU128(neg, q, r, factor, mode)
+				if err == nil {
+					return newDecimal(neg, bintFromU128(q), prec)
+				}
+			}
+		}
+	}
+
+	// overflow, fallback to big.Int
+	coef := bintFromBigInt(new(big.Int).Mul(d.coef.GetBig(), e.coef.GetBig()))
+	if exactPrec <= prec {
+		return newDecimal(neg, coef, exactPrec)
+	}
+
+	return newDecimal(neg, roundCoef(neg, coef, exactPrec-prec, mode), prec)
+}
+
+// DivRound returns d / e rounded to prec digits after the decimal point using the given rounding mode.
+// Unlike Div, the exact quotient is rounded directly instead of being truncated to defaultPrec first.
+// If prec > defaultPrec, defaultPrec is used.
+//
+// Returns divide by zero error when e is zero.
+//
+// Examples:
+//
+//	DivRound(2, 3, 2, RoundHalfEven) = 0.67
+//	DivRound(2, 3, 2, RoundDown) = 0.66
+//	DivRound(-1, 8, 2, RoundHalfEven) = -0.12
+func (d Decimal) DivRound(e Decimal, prec uint8, mode RoundingMode) (Decimal, error) {
+	return d.divRound(e, min(prec, defaultPrec), mode)
+}
+
+// divRound is DivRound with prec <= maxPrec, which doesn't depend on defaultPrec
+func (d Decimal) divRound(e Decimal, prec uint8, mode RoundingMode) (Decimal, error) {
+	if e.coef.IsZero() {
+		return Decimal{}, ErrDivideByZero
+	}
+
+	neg := d.neg != e.neg
+
+	// d / e = (d.coef * 10^(prec + e.prec - d.prec) / e.coef) / 10^prec
+	// Move 10^(d.prec - prec - e.prec) to the divisor if the exponent is negative.
+	exp := int(prec) + int(e.prec) - int(d.prec)
+
+	if !d.coef.overflow() && !e.coef.overflow() {
+		q, err := tryDivRoundU128(d.coef.u128, e.coef.u128, exp, neg, mode)
+		if err == nil {
+			return newDecimal(neg, bintFromU128(q), prec), nil
+		}
+	}
+
+	// overflow, fallback to big.Int
+	num := d.coef.GetBig()
+	den := e.coef.GetBig()
+	if exp >= 0 {
+		num.Mul(num, pow10BigInt(exp))
+	} else {
+		den.Mul(den, pow10BigInt(-exp))
+	}
+
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	return newDecimal(neg, bintFromBigInt(roundQuoBig(neg, q, r, den, mode)), prec), nil
+}
+
+func tryDivRoundU128(num, den u128, exp int, neg bool, mode RoundingMode) (u128, error) {
+	var err error
+
+	if exp < 0 {
+		den, err = den.Mul(pow10[-exp])
+		if err != nil {
+			return u128{}, err
+		}
+
+		exp = 0
+	}
+
+	q, r, err := num.MulToU256(pow10[exp]).fastQuo(den)
+	if err != nil {
+		return u128{}, err
+	}
+
+	return roundQuoU128(neg, q, r, den, mode)
+}
+
+// SqrtRound returns the square root of d rounded to prec digits after the decimal point using the given rounding mode.
+// Unlike Sqrt, the exact square root is rounded directly instead of being truncated to defaultPrec first.
+// If prec > defaultPrec, defaultPrec is used.
+//
+// Returns error if d < 0
+//
+// Examples:
+//
+//	SqrtRound(2, 2, RoundHalfEven) = 1.41
+//	SqrtRound(2, 2, RoundCeiling) = 1.42
+//	SqrtRound(2.25, 0, RoundHalfEven) = 2
+func (d Decimal) SqrtRound(prec uint8, mode RoundingMode) (Decimal, error) {
+	return d.sqrtRound(min(prec, defaultPrec), mode)
+}
+
+// sqrtRound is SqrtRound with prec <= maxPrec, which doesn't depend on defaultPrec
+func (d Decimal) sqrtRound(prec uint8, mode RoundingMode) (Decimal, error) {
+	if d.neg {
+		return Decimal{}, ErrSqrtNegative
+	}
+
+	if d.coef.IsZero() {
+		return Zero, nil
+	}
+
+	// The integer square root needs an even number of digits after the decimal point,
+	// compute it with p >= prec digits then round it to prec digits.
+	// sqrt(d) * 10^p = sqrt(d.coef * 10^(2p - d.prec)) = s + f, where s is an integer and 0 <= f < 1
+	p := max(prec, (d.prec+1)/2)
+
+	if !d.coef.overflow() {
+		q, err := trySqrtRoundU128(d.coef.u128, 2*p-d.prec, p-prec, mode)
+		if err == nil {
+			return newDecimal(false, bintFromU128(q), prec), nil
+		}
+	}
+
+	// overflow, fallback to big.Int
+	coef := d.coef.GetBig()
+	coef.Mul(coef, pow10BigInt(int(2*p-d.prec)))
+
+	s := new(big.Int).Sqrt(coef)
+	rem := new(big.Int).Sub(coef, new(big.Int).Mul(s, s))
+
+	var q, r, half *big.Int
+	if p == prec {
+		// s + f >= s + 1/2 <=> coef >= s^2 + s + 1/4 <=> rem > s
+		q, r, half = s, rem, s
+	} else {
+		factor := pow10BigInt(int(p - prec))
+		q, r = new(big.Int).QuoRem(s, factor, new(big.Int))
+		half = new(big.Int).Rsh(factor, 1)
+	}
+
+	if r.Sign() == 0 && rem.Sign() == 0 {
+		return newDecimal(false, bintFromBigInt(q), prec), nil
+	}
+
+	cmpHalf := r.Cmp(half)
+	if p == prec && cmpHalf == 0 {
+		cmpHalf = -1
+	} else if p != prec && cmpHalf == 0 && rem.Sign() != 0 {
+		// f > 0 makes the discarded part greater than a half
+		cmpHalf = 1
+	}
+
+	digit := new(big.Int).Rem(q, bigTen).Uint64()
+	if mode.roundUp(false, digit, cmpHalf) {
+		q.Add(q, bigOne)
+	}
+
+	return newDecimal(false, bintFromBigInt(q), prec), nil
+}
+
+// trySqrtRoundU128 returns sqrt(coef * 10^scale) / 10^n rounded with the given mode
+func trySqrtRoundU128(coef u128, scale, n uint8, mode RoundingMode) (u128, error) {
+	c := coef.MulToU256(pow10[scale])
+
+	s, err := sqrtU256(c)
+	if err != nil {
+		return u128{}, err
+	}
+
+	// rem = c - s^2 <= 2s fits into u128, so only the lower 128 bits are needed
+	s2 := s.MulToU256(s)
+	lo, borrow := bits.Sub64(c.lo, s2.lo, 0)
+	hi, _ := bits.Sub64(c.hi, s2.hi, borrow)
+	rem := u128{hi: hi, lo: lo}
+
+	var (
+		q, r u128
+		cmp  int
+	)
+
+	if n == 0 {
+		if rem.IsZero() {
+			return s, nil
+		}
+
+		// s + f >= s + 1/2 <=> c >= s^2 + s + 1/4 <=> rem > s, and it can't be a tie
+		q = s
+		if rem.Cmp(s) > 0 {
+			cmp = 1
+		} else {
+			cmp = -1
+		}
+	} else {
+		factor := pow10[n]
+		q, r, err = s.QuoRem(factor)
+		if err != nil {
+			return u128{}, err
+		}
+
+		if r.IsZero() && rem.IsZero() {
+			return q, nil
+		}
+
+		// f > 0 makes the discarded part greater than a half
+		cmp = r.Lsh(1).Cmp(factor)
+		if cmp == 0 && !rem.IsZero() {
+			cmp = 1
+		}
+	}
+
+	_, digit := q.QuoRem64(10)
+	if mode.roundUp(false, digit, cmp) {
+		return q.Add64(1)
+	}
+
+	return q, nil
+}
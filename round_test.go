@@ -0,0 +1,467 @@
+package udecimal
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var allRoundingModes = []RoundingMode{
+	RoundHalfEven,
+	RoundHalfUp,
+	RoundHalfDown,
+	RoundUp,
+	RoundDown,
+	RoundCeiling,
+	RoundFloor,
+	RoundHalfOdd,
+	Round05Up,
+}
+
+func TestRound(t *testing.T) {
+	// want is ordered as allRoundingModes
+	testcases := []struct {
+		a    string
+		prec uint8
+		want [9]string
+	}{
+		{"1.25", 1, [9]string{"1.2", "1.3", "1.2", "1.3", "1.2", "1.3", "1.2", "1.3", "1.2"}},
+		{"1.35", 1, [9]string{"1.4", "1.4", "1.3", "1.4", "1.3", "1.4", "1.3", "1.3", "1.3"}},
+		{"-1.25", 1, [9]string{"-1.2", "-1.3", "-1.2", "-1.3", "-1.2", "-1.2", "-1.3", "-1.3", "-1.2"}},
+		{"-1.35", 1, [9]string{"-1.4", "-1.4", "-1.3", "-1.4", "-1.3", "-1.3", "-1.4", "-1.3", "-1.3"}},
+		{"1.21", 1, [9]string{"1.2", "1.2", "1.2", "1.3", "1.2", "1.3", "1.2", "1.2", "1.2"}},
+		{"-1.21", 1, [9]string{"-1.2", "-1.2", "-1.2", "-1.3", "-1.2", "-1.2", "-1.3", "-1.2", "-1.2"}},
+		{"1.29", 1, [9]string{"1.3", "1.3", "1.3", "1.3", "1.2", "1.3", "1.2", "1.3", "1.2"}},
+		{"-1.29", 1, [9]string{"-1.3", "-1.3", "-1.3", "-1.3", "-1.2", "-1.2", "-1.3", "-1.3", "-1.2"}},
+		{"1.01", 1, [9]string{"1", "1", "1", "1.1", "1", "1.1", "1", "1", "1.1"}},
+		{"1.51", 1, [9]string{"1.5", "1.5", "1.5", "1.6", "1.5", "1.6", "1.5", "1.5", "1.6"}},
+		{"-1.01", 1, [9]string{"-1", "-1", "-1", "-1.1", "-1", "-1", "-1.1", "-1", "-1.1"}},
+		{"0.5", 0, [9]string{"0", "1", "0", "1", "0", "1", "0", "1", "1"}},
+		{"-0.5", 0, [9]string{"0", "-1", "0", "-1", "0", "0", "-1", "-1", "-1"}},
+		{"2.5", 0, [9]string{"2", "3", "2", "3", "2", "3", "2", "3", "2"}},
+		{"0.05", 1, [9]string{"0", "0.1", "0", "0.1", "0", "0.1", "0", "0.1", "0.1"}},
+		{"-0.04", 1, [9]string{"0", "0", "0", "-0.1", "0", "0", "-0.1", "0", "-0.1"}},
+		{"123.456789", 4, [9]string{"123.4568", "123.4568", "123.4568", "123.4568", "123.4567", "123.4568", "123.4567", "123.4568", "123.4567"}},
+		{"9.99", 1, [9]string{"10", "10", "10", "10", "9.9", "10", "9.9", "10", "9.9"}},
+		{"-9.99", 1, [9]string{"-10", "-10", "-10", "-10", "-9.9", "-9.9", "-10", "-10", "-9.9"}},
+		{"1.0000000000000000005", 18, [9]string{"1", "1.000000000000000001", "1", "1.000000000000000001", "1", "1.000000000000000001", "1", "1.000000000000000001", "1.000000000000000001"}},
+		{"-1.0000000000000000005", 18, [9]string{"-1", "-1.000000000000000001", "-1", "-1.000000000000000001", "-1", "-1", "-1.000000000000000001", "-1.000000000000000001", "-1.000000000000000001"}},
+		{"12345678901234567890123456789.1234567890123456785", 18, [9]string{"12345678901234567890123456789.123456789012345678", "12345678901234567890123456789.123456789012345679", "12345678901234567890123456789.123456789012345678", "12345678901234567890123456789.123456789012345679", "12345678901234567890123456789.123456789012345678", "12345678901234567890123456789.123456789012345679", "12345678901234567890123456789.123456789012345678", "12345678901234567890123456789.123456789012345679", "12345678901234567890123456789.123456789012345678"}},
+		{"-12345678901234567890123456789.1234567890123456785", 18, [9]string{"-12345678901234567890123456789.123456789012345678", "-12345678901234567890123456789.123456789012345679", "-12345678901234567890123456789.123456789012345678", "-12345678901234567890123456789.123456789012345679", "-12345678901234567890123456789.123456789012345678", "-12345678901234567890123456789.123456789012345678", "-12345678901234567890123456789.123456789012345679", "-12345678901234567890123456789.123456789012345679", "-12345678901234567890123456789.123456789012345678"}},
+		{"123456789012345678901234567890123456789.5", 0, [9]string{"123456789012345678901234567890123456790", "123456789012345678901234567890123456790", "123456789012345678901234567890123456789", "123456789012345678901234567890123456790", "123456789012345678901234567890123456789", "123456789012345678901234567890123456790", "123456789012345678901234567890123456789", "123456789012345678901234567890123456789", "123456789012345678901234567890123456789"}},
+		{"-123456789012345678901234567890123456789.5", 0, [9]string{"-123456789012345678901234567890123456790", "-123456789012345678901234567890123456790", "-123456789012345678901234567890123456789", "-123456789012345678901234567890123456790", "-123456789012345678901234567890123456789", "-123456789012345678901234567890123456789", "-123456789012345678901234567890123456790", "-123456789012345678901234567890123456789", "-123456789012345678901234567890123456789"}},
+		{"0.0000000000000000001", 0, [9]string{"0", "0", "0", "1", "0", "1", "0", "0", "1"}},
+		{"1.23", 2, [9]string{"1.23", "1.23", "1.23", "1.23", "1.23", "1.23", "1.23", "1.23", "1.23"}},
+		{"1.23", 5, [9]string{"1.23", "1.23", "1.23", "1.23", "1.23", "1.23", "1.23", "1.23", "1.23"}},
+		{"0", 0, [9]string{"0", "0", "0", "0", "0", "0", "0", "0", "0"}},
+	}
+
+	for _, tc := range testcases {
+		for i, mode := range allRoundingModes {
+			t.Run(fmt.Sprintf("round(%s, %d, %s)", tc.a, tc.prec, mode), func(t *testing.T) {
+				a, err := Parse(tc.a)
+				require.NoError(t, err)
+
+				aStr := a.String()
+
+				b := a.Round(tc.prec, mode)
+				require.Equal(t, tc.want[i], b.String())
+
+				// make sure a is immutable
+				require.Equal(t, aStr, a.String())
+			})
+		}
+	}
+}
+
+func TestRoundConsistency(t *testing.T) {
+	inputs := []string{"1.12345", "1.12335", "1.5", "-1.5", "1.12", "1.15", "-1.12", "-1.15", "123.4567", "-0.0000000000000000005"}
+
+	for _, input := range inputs {
+		a := MustParse(input)
+		for prec := uint8(0); prec <= 5; prec++ {
+			require.Equal(t, a.RoundBank(prec), a.Round(prec, RoundHalfEven))
+			require.Equal(t, a.RoundHAZ(prec), a.Round(prec, RoundHalfUp))
+			require.Equal(t, a.RoundHTZ(prec), a.Round(prec, RoundHalfDown))
+			require.Equal(t, a.RoundAwayFromZero(prec), a.Round(prec, RoundUp))
+			require.Equal(t, a.Trunc(prec), a.Round(prec, RoundDown))
+		}
+
+		require.Equal(t, a.Floor(), a.FloorPrec(0))
+		require.Equal(t, a.Ceil(), a.CeilPrec(0))
+	}
+}
+
+func TestFloorCeilPrec(t *testing.T) {
+	testcases := []struct {
+		a         string
+		prec      uint8
+		wantFloor string
+		wantCeil  string
+	}{
+		{"1.129", 2, "1.12", "1.13"},
+		{"-1.121", 2, "-1.13", "-1.12"},
+		{"1.12", 2, "1.12", "1.12"},
+		{"-0.001", 2, "-0.01", "0"},
+		{"0.001", 2, "0", "0.01"},
+		{"123456789012345678901234567890.1234567890123456789", 18, "123456789012345678901234567890.123456789012345678", "123456789012345678901234567890.123456789012345679"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%d", tc.a, tc.prec), func(t *testing.T) {
+			a := MustParse(tc.a)
+			require.Equal(t, tc.wantFloor, a.FloorPrec(tc.prec).String())
+			require.Equal(t, tc.wantCeil, a.CeilPrec(tc.prec).String())
+		})
+	}
+}
+
+func TestRoundInvalidMode(t *testing.T) {
+	require.PanicsWithValue(t, "can't round: invalid rounding mode 100", func() {
+		_ = MustParse("1.25").Round(1, RoundingMode(100))
+	})
+
+	// no rounding needed, no panic
+	require.NotPanics(t, func() {
+		_ = MustParse("1.2").Round(1, RoundingMode(100))
+	})
+}
+
+func TestRoundingModeText(t *testing.T) {
+	for _, mode := range allRoundingModes {
+		b, err := mode.MarshalText()
+		require.NoError(t, err)
+
+		var m RoundingMode
+		require.NoError(t, m.UnmarshalText(b))
+		require.Equal(t, mode, m)
+	}
+
+	var m RoundingMode
+	require.NoError(t, m.UnmarshalText([]byte("HALF-UP")))
+	require.Equal(t, RoundHalfUp, m)
+
+	require.ErrorIs(t, m.UnmarshalText([]byte("nearest")), ErrInvalidRoundingMode)
+
+	_, err := RoundingMode(100).MarshalText()
+	require.ErrorIs(t, err, ErrInvalidRoundingMode)
+	require.Equal(t, "RoundingMode(100)", RoundingMode(100).String())
+
+	// can be loaded from configuration
+	var cfg struct {
+		Mode RoundingMode `json:"mode"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"mode":"ceiling"}`), &cfg))
+	require.Equal(t, RoundCeiling, cfg.Mode)
+}
+
+func TestRoundToIncrement(t *testing.T) {
+	testcases := []struct {
+		a, step string
+		mode    RoundingMode
+		want    string
+		wantErr error
+	}{
+		{"1.37", "0", RoundHalfEven, "", ErrInvalidIncrement},
+		{"1.37", "-0.25", RoundHalfEven, "", ErrInvalidIncrement},
+		{"0", "0.25", RoundUp, "0", nil},
+		{"1.37", "0.25", RoundHalfEven, "1.25", nil},
+		{"1.375", "0.25", RoundHalfEven, "1.5", nil},
+		{"1.125", "0.25", RoundHalfEven, "1", nil},
+		{"1.125", "0.25", RoundHalfUp, "1.25", nil},
+		{"1.125", "0.25", RoundHalfDown, "1", nil},
+		{"1.025", "0.05", RoundHalfUp, "1.05", nil},
+		{"1.025", "0.05", RoundHalfEven, "1", nil},
+		{"1.075", "0.05", RoundHalfEven, "1.1", nil},
+		{"-1.01", "0.05", RoundFloor, "-1.05", nil},
+		{"-1.01", "0.05", RoundCeiling, "-1", nil},
+		{"-1.01", "0.05", RoundUp, "-1.05", nil},
+		{"-1.04", "0.05", RoundDown, "-1", nil},
+		{"123.4567", "0.005", RoundHalfEven, "123.455", nil},
+		{"-0.0024", "0.005", RoundHalfUp, "0", nil},
+		{"-0.0025", "0.005", RoundHalfUp, "-0.005", nil},
+		{"7", "3", RoundHalfEven, "6", nil},
+		{"-8", "3", RoundHalfEven, "-9", nil},
+		{"1.26", "0.25", Round05Up, "1.5", nil},
+		{"1.51", "0.25", Round05Up, "1.5", nil},
+		{"1.5", "0.25", RoundUp, "1.5", nil},
+		{"12345678901234567890123456789.123", "0.25", RoundCeiling, "12345678901234567890123456789.25", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%s_%s", tc.a, tc.step, tc.mode), func(t *testing.T) {
+			a := MustParse(tc.a)
+			step := MustParse(tc.step)
+
+			got, err := a.RoundToIncrement(step, tc.mode)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got.String())
+			require.True(t, got.IsMultipleOf(step))
+		})
+	}
+}
+
+func TestRoundToIncrementConsistency(t *testing.T) {
+	// rounding to 10^-prec must match Round
+	inputs := []string{"0", "1.25", "-1.25", "1.35", "-1.35", "1.2501", "-1.2499", "123.456789", "-0.0000000000000000015"}
+
+	for _, s := range inputs {
+		for prec := uint8(0); prec <= 19; prec++ {
+			for _, mode := range allRoundingModes {
+				d := MustParse(s)
+				step := MustFromUint64(1, prec)
+
+				got, err := d.RoundToIncrement(step, mode)
+				require.NoError(t, err)
+				require.Equal(t, d.Round(prec, mode).String(), got.String(), "%s %d %s", s, prec, mode)
+			}
+		}
+	}
+}
+
+func TestIsMultipleOf(t *testing.T) {
+	testcases := []struct {
+		a, step string
+		want    bool
+	}{
+		{"0", "0", true},
+		{"1", "0", false},
+		{"0", "0.25", true},
+		{"1.75", "0.25", true},
+		{"-1.75", "0.25", true},
+		{"1.75", "-0.25", true},
+		{"1.8", "0.25", false},
+		{"1.05", "0.05", true},
+		{"1.03", "0.05", false},
+		{"0.0000000000000000001", "0.0000000000000000001", true},
+		{"123456789012345678901234567890", "3", true},
+		{"123456789012345678901234567891", "3", false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%s", tc.a, tc.step), func(t *testing.T) {
+			require.Equal(t, tc.want, MustParse(tc.a).IsMultipleOf(MustParse(tc.step)))
+		})
+	}
+}
+
+func TestRoundSig(t *testing.T) {
+	testcases := []struct {
+		a    string
+		n    uint8
+		mode RoundingMode
+		want string
+	}{
+		{"0", 3, RoundUp, "0"},
+		{"123.456", 0, RoundUp, "0"},
+		{"0.000123456", 3, RoundHalfEven, "0.000123"},
+		{"123456", 3, RoundHalfEven, "123000"},
+		{"-987.65", 2, RoundFloor, "-990"},
+		{"9.99", 2, RoundHalfUp, "10"},
+		{"9.99", 2, RoundDown, "9.9"},
+		{"0.0000000000000000015", 1, RoundHalfEven, "0.000000000000000002"},
+		{"0.0000000000000000025", 1, RoundHalfEven, "0.000000000000000002"},
+		{"1.25", 2, RoundHalfEven, "1.2"},
+		{"-1.25", 2, RoundHalfUp, "-1.3"},
+		{"125", 2, RoundHalfOdd, "130"},
+		{"12345.6789", 19, RoundHalfEven, "12345.6789"},
+		{"12345.6789", 6, RoundHalfEven, "12345.7"},
+		{"12345.6789", 1, RoundUp, "20000"},
+		{"1000", 1, RoundUp, "1000"},
+		{"123456789012345678901234567890123456789", 5, RoundHalfEven, "123460000000000000000000000000000000000"},
+		{"999999999999999999999999999999999999999999999", 3, RoundHalfUp, "1000000000000000000000000000000000000000000000"},
+		{"123456789012345678901234567890123456789012345.5", 40, RoundUp, "123456789012345678901234567890123456789100000"},
+		{"123456789012345678901234567890123456789012345.5", 2, RoundCeiling, "130000000000000000000000000000000000000000000"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s_%d_%s", tc.a, tc.n, tc.mode), func(t *testing.T) {
+			a := MustParse(tc.a)
+
+			got := a.RoundSig(tc.n, tc.mode)
+			require.Equal(t, tc.want, got.String())
+			require.LessOrEqual(t, got.NumSignificantDigits(), int(tc.n))
+		})
+	}
+}
+
+func TestNumSignificantDigits(t *testing.T) {
+	testcases := []struct {
+		a    string
+		want int
+	}{
+		{"0", 0},
+		{"0.000", 0},
+		{"1", 1},
+		{"-7", 1},
+		{"0.000123", 3},
+		{"123000", 3},
+		{"1.20", 2},
+		{"100.001", 6},
+		{"0.0000000000000000001", 1},
+		{"10000000000000000000000000000000000000", 1},
+		{maxU128Str, 39},
+		{"123456789012345678901234567890123456789012345.5", 46},
+		{"100000000000000000000000000000000000000000000000", 1},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.a, func(t *testing.T) {
+			require.Equal(t, tc.want, MustParse(tc.a).NumSignificantDigits())
+		})
+	}
+}
+
+func TestMulRound(t *testing.T) {
+	testcases := []struct {
+		a, b string
+		prec uint8
+		mode RoundingMode
+		want string
+	}{
+		{"1.15", "0.5", 2, RoundHalfEven, "0.58"},
+		{"1.15", "0.5", 2, RoundDown, "0.57"},
+		{"-1.15", "0.5", 2, RoundHalfUp, "-0.58"},
+		{"-1.15", "0.5", 2, RoundCeiling, "-0.57"},
+		{"1.23", "4.56", 5, RoundHalfEven, "5.6088"},
+		{"1.23", "4.56", 0, RoundUp, "6"},
+		{"0", "4.56", 0, RoundUp, "0"},
+		{"0.0000000000000000001", "0.5", 19, RoundHalfEven, "0"},
+		{"0.0000000000000000001", "0.5", 19, RoundUp, "0.0000000000000000001"},
+		{"0.0000000000000000001", "0.5", 30, RoundUp, "0.0000000000000000001"},
+		{"123456789012345678901234567890.123456789", "1.0000000000000000005", 18, RoundHalfEven, "123456789012345678962962962396.296296239617283945"},
+		{"123456789012345678901234567890.123456789", "-1.0000000000000000005", 18, RoundFloor, "-123456789012345678962962962396.296296239617283946"},
+		{"123456789012345678901234567890123456789", "10", 0, RoundHalfEven, "1234567890123456789012345678901234567890"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s*%s_%d_%s", tc.a, tc.b, tc.prec, tc.mode), func(t *testing.T) {
+			a := MustParse(tc.a)
+			b := MustParse(tc.b)
+
+			require.Equal(t, tc.want, a.MulRound(b, tc.prec, tc.mode).String())
+			require.Equal(t, tc.want, b.MulRound(a, tc.prec, tc.mode).String())
+		})
+	}
+}
+
+func TestDivRound(t *testing.T) {
+	testcases := []struct {
+		a, b    string
+		prec    uint8
+		mode    RoundingMode
+		want    string
+		wantErr error
+	}{
+		{"1", "0", 2, RoundHalfEven, "", ErrDivideByZero},
+		{"2", "3", 2, RoundHalfEven, "0.67", nil},
+		{"2", "3", 2, RoundDown, "0.66", nil},
+		{"-2", "3", 2, RoundFloor, "-0.67", nil},
+		{"-2", "3", 2, RoundCeiling, "-0.66", nil},
+		{"-1", "8", 2, RoundHalfEven, "-0.12", nil},
+		{"-1", "8", 2, RoundHalfUp, "-0.13", nil},
+		{"1", "8", 2, RoundHalfDown, "0.12", nil},
+		{"1", "8", 2, RoundHalfOdd, "0.13", nil},
+		{"100", "0.7", 0, RoundHalfEven, "143", nil},
+		{"10", "4", 0, RoundHalfEven, "2", nil},
+		{"10", "4", 0, RoundHalfOdd, "3", nil},
+		{"1.2345", "1", 2, RoundHalfUp, "1.23", nil},
+		{"1.2355", "0.001", 0, RoundHalfUp, "1236", nil},
+		{"1", "3", 25, RoundUp, "0.3333333333333333334", nil},
+		{"123456789012345678901234567890", "7", 19, RoundHalfEven, "17636684144620811271604938270", nil},
+		{"123456789012345678901234567891", "7", 19, RoundHalfEven, "17636684144620811271604938270.1428571428571428571", nil},
+		{"123456789012345678901234567891", "-7", 19, RoundUp, "-17636684144620811271604938270.1428571428571428572", nil},
+		{"1", "123456789012345678901234567890123456789", 19, RoundUp, "0.0000000000000000001", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s/%s_%d_%s", tc.a, tc.b, tc.prec, tc.mode), func(t *testing.T) {
+			a := MustParse(tc.a)
+			b := MustParse(tc.b)
+
+			q, err := a.DivRound(b, tc.prec, tc.mode)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, q.String())
+		})
+	}
+}
+
+func TestSqrtRound(t *testing.T) {
+	testcases := []struct {
+		a       string
+		prec    uint8
+		mode    RoundingMode
+		want    string
+		wantErr error
+	}{
+		{"-1", 2, RoundHalfEven, "", ErrSqrtNegative},
+		{"0", 2, RoundUp, "0", nil},
+		{"2", 2, RoundHalfEven, "1.41", nil},
+		{"2", 2, RoundCeiling, "1.42", nil},
+		{"2", 19, RoundHalfEven, "1.4142135623730950488", nil},
+		{"2.25", 0, RoundHalfEven, "2", nil},
+		{"2.25", 0, RoundHalfUp, "2", nil},
+		{"2.25", 0, RoundDown, "1", nil},
+		{"6.25", 0, RoundHalfEven, "2", nil},
+		{"6.25", 0, RoundHalfOdd, "3", nil},
+		{"3", 0, RoundHalfEven, "2", nil},
+		{"0.0001", 2, RoundUp, "0.01", nil},
+		{"0.00015", 2, RoundFloor, "0.01", nil},
+		{"0.00015", 2, RoundCeiling, "0.02", nil},
+		{"0.0000000000000000025", 9, RoundHalfEven, "0.000000002", nil},
+		{"0.0000000000000000025", 8, RoundHalfEven, "0", nil},
+		{"123456789012345678901234567890123456789012345.6789", 19, RoundHalfUp, "11111111061111110993611.1105818611081081548", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("sqrt(%s)_%d_%s", tc.a, tc.prec, tc.mode), func(t *testing.T) {
+			a := MustParse(tc.a)
+
+			q, err := a.SqrtRound(tc.prec, tc.mode)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, q.String())
+		})
+	}
+}
+
+func TestRoundOpsConsistency(t *testing.T) {
+	inputs := []string{"1", "-2.5", "3.1415926535", "0.0000000000000000007", "123456789.987654321", "-98765432109876543210.0123456789"}
+
+	for _, a := range inputs {
+		for _, b := range inputs {
+			d, e := MustParse(a), MustParse(b)
+
+			require.Equal(t, d.Mul(e), d.MulRound(e, defaultPrec, RoundDown))
+
+			q1, err := d.Div(e)
+			require.NoError(t, err)
+
+			q2, err := d.DivRound(e, defaultPrec, RoundDown)
+			require.NoError(t, err)
+			require.Equal(t, q1, q2)
+		}
+
+		s1, err1 := MustParse(a).Sqrt()
+		s2, err2 := MustParse(a).SqrtRound(defaultPrec, RoundDown)
+		require.Equal(t, err1, err2)
+		require.True(t, s1.Equal(s2))
+	}
+}
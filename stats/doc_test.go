@@ -0,0 +1,61 @@
+package stats
+
+import (
+	"fmt"
+
+	"github.com/markovichecha/udecimal"
+)
+
+func ExampleMean() {
+	xs := []udecimal.Decimal{udecimal.MustParse("1"), udecimal.MustParse("2"), udecimal.MustParse("4")}
+	fmt.Println(Mean(xs))
+	fmt.Println(Mean(nil))
+	// Output:
+	// 2.3333333333333333333 <nil>
+	// 0 stats: empty input
+}
+
+func ExampleWeightedMean() {
+	prices := []udecimal.Decimal{udecimal.MustParse("10.5"), udecimal.MustParse("20.25"), udecimal.MustParse("30")}
+	weights := []udecimal.Decimal{udecimal.MustParse("0.2"), udecimal.MustParse("0.3"), udecimal.MustParse("0.5")}
+	fmt.Println(WeightedMean(prices, weights))
+	// Output:
+	// 23.175 <nil>
+}
+
+func ExamplePercentile() {
+	xs := []udecimal.Decimal{
+		udecimal.MustParse("15"),
+		udecimal.MustParse("20"),
+		udecimal.MustParse("35"),
+		udecimal.MustParse("40"),
+		udecimal.MustParse("50"),
+	}
+
+	fmt.Println(Percentile(xs, udecimal.MustParse("40"), Linear))
+	fmt.Println(Percentile(xs, udecimal.MustParse("40"), Lower))
+	fmt.Println(Percentile(xs, udecimal.MustParse("40"), Midpoint))
+	// Output:
+	// 29 <nil>
+	// 20 <nil>
+	// 27.5 <nil>
+}
+
+func ExampleStdDev() {
+	xs := []udecimal.Decimal{udecimal.MustParse("1"), udecimal.MustParse("2"), udecimal.MustParse("3"), udecimal.MustParse("4")}
+	fmt.Println(Variance(xs))
+	fmt.Println(StdDev(xs))
+	// Output:
+	// 1.6666666666666666666 <nil>
+	// 1.2909944487358056283 <nil>
+}
+
+func ExampleCorrelation() {
+	xs := []udecimal.Decimal{udecimal.MustParse("1"), udecimal.MustParse("2"), udecimal.MustParse("3")}
+	ys := []udecimal.Decimal{udecimal.MustParse("2"), udecimal.MustParse("4"), udecimal.MustParse("6")}
+	fmt.Println(Covariance(xs, ys))
+	fmt.Println(Correlation(xs, ys))
+	// Output:
+	// 2 <nil>
+	// 1 <nil>
+}
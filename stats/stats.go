@@ -0,0 +1,372 @@
+// Package stats provides descriptive statistics over slices of [udecimal.Decimal].
+//
+// Sums of values, squares and products are accumulated exactly (falling back to big.Int when needed),
+// and each function rounds only once at the end. Like the udecimal package, the final result is
+// truncated to the default precision, see [udecimal.SetDefaultPrecision].
+package stats
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/markovichecha/udecimal"
+)
+
+var (
+	// ErrEmpty is returned when the input has no values
+	ErrEmpty = fmt.Errorf("stats: empty input")
+
+	// ErrLengthMismatch is returned when paired inputs (values and weights, x and y) have different lengths
+	ErrLengthMismatch = fmt.Errorf("stats: inputs have different lengths")
+
+	// ErrInvalidPercentile is returned when the percentile is not in the range [0, 100]
+	ErrInvalidPercentile = fmt.Errorf("stats: percentile must be between 0 and 100")
+
+	// ErrInvalidMethod is returned when the percentile method is unknown
+	ErrInvalidMethod = fmt.Errorf("stats: invalid percentile method")
+)
+
+var hundred = udecimal.MustFromUint64(100, 0)
+
+// PercentileMethod specifies how a percentile between two data points is computed.
+// The methods match the ones of numpy.percentile.
+type PercentileMethod uint8
+
+const (
+	// Linear interpolates between the two nearest data points.
+	Linear PercentileMethod = iota
+
+	// Lower takes the lower of the two nearest data points.
+	Lower
+
+	// Higher takes the higher of the two nearest data points.
+	Higher
+
+	// Nearest takes the nearest data point, ties go to the data point with the even index.
+	Nearest
+
+	// Midpoint takes the average of the two nearest data points.
+	Midpoint
+)
+
+// Sum returns the exact sum of xs. The sum of an empty slice is zero.
+func Sum(xs []udecimal.Decimal) udecimal.Decimal {
+	sum := udecimal.Zero
+	for _, x := range xs {
+		sum = sum.Add(x)
+	}
+
+	return sum
+}
+
+// Mean returns the arithmetic mean of xs.
+//
+// Returns [ErrEmpty] if xs is empty.
+func Mean(xs []udecimal.Decimal) (udecimal.Decimal, error) {
+	if len(xs) == 0 {
+		return udecimal.Decimal{}, ErrEmpty
+	}
+
+	return Sum(xs).Div(fromInt(len(xs)))
+}
+
+// WeightedMean returns sum(xs[i] * ws[i]) / sum(ws).
+//
+// Returns error if:
+//   - xs is empty ([ErrEmpty])
+//   - xs and ws have different lengths ([ErrLengthMismatch])
+//   - the sum of weights is zero ([udecimal.ErrDivideByZero])
+func WeightedMean(xs, ws []udecimal.Decimal) (udecimal.Decimal, error) {
+	if len(xs) == 0 {
+		return udecimal.Decimal{}, ErrEmpty
+	}
+
+	if len(xs) != len(ws) {
+		return udecimal.Decimal{}, ErrLengthMismatch
+	}
+
+	// x * w can have up to 38 digits after the decimal point, so scale both to integers first:
+	// sum(x*w) / sum(w) = sum(X*W) / (sum(W) * 10^px), where X = x * 10^px and W = w * 10^pw
+	xi, px := scale(xs)
+	wi, _ := scale(ws)
+
+	sumXW, sumW := udecimal.Zero, udecimal.Zero
+	for i := range xi {
+		sumXW = sumXW.Add(xi[i].Mul(wi[i]))
+		sumW = sumW.Add(wi[i])
+	}
+
+	return sumXW.Div(sumW.Mul(px))
+}
+
+// Median returns the middle value of xs, or the mean of the two middle values if len(xs) is even.
+//
+// Returns [ErrEmpty] if xs is empty.
+func Median(xs []udecimal.Decimal) (udecimal.Decimal, error) {
+	if len(xs) == 0 {
+		return udecimal.Decimal{}, ErrEmpty
+	}
+
+	sorted := sortedCopy(xs)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2], nil
+	}
+
+	return sorted[n/2-1].Add(sorted[n/2]).Div64(2)
+}
+
+// Percentile returns the p-th percentile of xs, where 0 <= p <= 100.
+// The rank of the percentile is (len(xs) - 1) * p / 100 and method decides
+// how a rank between two data points is resolved.
+//
+// Returns error if:
+//   - xs is empty ([ErrEmpty])
+//   - p is not in the range [0, 100] ([ErrInvalidPercentile])
+//   - method is unknown ([ErrInvalidMethod])
+func Percentile(xs []udecimal.Decimal, p udecimal.Decimal, method PercentileMethod) (udecimal.Decimal, error) {
+	if len(xs) == 0 {
+		return udecimal.Decimal{}, ErrEmpty
+	}
+
+	if p.IsNeg() || p.GreaterThan(hundred) {
+		return udecimal.Decimal{}, ErrInvalidPercentile
+	}
+
+	if method > Midpoint {
+		return udecimal.Decimal{}, ErrInvalidMethod
+	}
+
+	sorted := sortedCopy(xs)
+
+	// rank = (n-1) * p / 100 = k + r / 100, where k is an integer and 0 <= r < 100
+	q, r, err := fromInt(len(sorted) - 1).Mul(p).QuoRem(hundred)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	k64, err := q.Int64()
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	k := int(k64)
+	lo := sorted[k]
+	if r.IsZero() {
+		return lo, nil
+	}
+
+	hi := sorted[k+1]
+
+	switch method {
+	case Lower:
+		return lo, nil
+	case Higher:
+		return hi, nil
+	case Nearest:
+		switch c := r.Add(r).Cmp(hundred); {
+		case c < 0:
+			return lo, nil
+		case c > 0:
+			return hi, nil
+		case k%2 == 0:
+			return lo, nil
+		default:
+			return hi, nil
+		}
+	case Midpoint:
+		return lo.Add(hi).Div64(2)
+	default:
+		// lo + (hi - lo) * r / 100
+		d, err := hi.Sub(lo).MulDiv(r, hundred)
+		if err != nil {
+			return udecimal.Decimal{}, err
+		}
+
+		return lo.Add(d), nil
+	}
+}
+
+// Variance returns the sample variance of xs: sum((x - mean)^2) / (n - 1).
+//
+// Returns error if:
+//   - xs is empty ([ErrEmpty])
+//   - xs has only one value ([udecimal.ErrDivideByZero])
+func Variance(xs []udecimal.Decimal) (udecimal.Decimal, error) {
+	num, den, err := variance(xs)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return num.Div(den)
+}
+
+// StdDev returns the sample standard deviation of xs, which is the square root of [Variance].
+//
+// Returns error if:
+//   - xs is empty ([ErrEmpty])
+//   - xs has only one value ([udecimal.ErrDivideByZero])
+func StdDev(xs []udecimal.Decimal) (udecimal.Decimal, error) {
+	num, den, err := variance(xs)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return sqrtQuo(num, den)
+}
+
+// Covariance returns the sample covariance of xs and ys: sum((x - mean(x)) * (y - mean(y))) / (n - 1).
+//
+// Returns error if:
+//   - xs is empty ([ErrEmpty])
+//   - xs and ys have different lengths ([ErrLengthMismatch])
+//   - xs has only one value ([udecimal.ErrDivideByZero])
+func Covariance(xs, ys []udecimal.Decimal) (udecimal.Decimal, error) {
+	if err := checkPair(xs, ys); err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	xi, px := scale(xs)
+	yi, py := scale(ys)
+
+	// n * sum(X*Y) - sum(X) * sum(Y) / (n * (n-1) * 10^px * 10^py)
+	n := fromInt(len(xs))
+	num := coMoment(xi, yi)
+	den := n.Mul(fromInt(len(xs) - 1)).Mul(px).Mul(py)
+	return num.Div(den)
+}
+
+// Correlation returns the Pearson correlation coefficient of xs and ys,
+// which is in the range [-1, 1].
+//
+// Returns error if:
+//   - xs is empty ([ErrEmpty])
+//   - xs and ys have different lengths ([ErrLengthMismatch])
+//   - xs or ys has zero variance ([udecimal.ErrDivideByZero])
+func Correlation(xs, ys []udecimal.Decimal) (udecimal.Decimal, error) {
+	if err := checkPair(xs, ys); err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	xi, _ := scale(xs)
+	yi, _ := scale(ys)
+
+	// the scale factors cancel out:
+	// corr = cov(X, Y) / sqrt(var(X) * var(Y)) = sign(num) * sqrt(num^2 / (varX * varY))
+	num := coMoment(xi, yi)
+	den := coMoment(xi, xi).Mul(coMoment(yi, yi))
+
+	c, err := sqrtQuo(num.Mul(num), den)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	if num.IsNeg() {
+		return c.Neg(), nil
+	}
+
+	return c, nil
+}
+
+// variance returns the numerator and denominator of the sample variance of xs
+func variance(xs []udecimal.Decimal) (num, den udecimal.Decimal, err error) {
+	if len(xs) == 0 {
+		return udecimal.Decimal{}, udecimal.Decimal{}, ErrEmpty
+	}
+
+	xi, px := scale(xs)
+
+	// (n * sum(X^2) - sum(X)^2) / (n * (n-1) * 10^(2*px))
+	n := fromInt(len(xs))
+	num = coMoment(xi, xi)
+	den = n.Mul(fromInt(len(xs) - 1)).Mul(px).Mul(px)
+	return num, den, nil
+}
+
+// coMoment returns n * sum(xs[i] * ys[i]) - sum(xs) * sum(ys), which is exact for integers
+func coMoment(xs, ys []udecimal.Decimal) udecimal.Decimal {
+	sumX, sumY, sumXY := udecimal.Zero, udecimal.Zero, udecimal.Zero
+	for i := range xs {
+		sumX = sumX.Add(xs[i])
+		sumY = sumY.Add(ys[i])
+		sumXY = sumXY.Add(xs[i].Mul(ys[i]))
+	}
+
+	return fromInt(len(xs)).Mul(sumXY).Sub(sumX.Mul(sumY))
+}
+
+// sqrtQuo returns sqrt(num / den) truncated to the default precision, where num and den are non-negative integers.
+// The quotient is computed with 38 digits after the decimal point so the result is truncated only once:
+// floor(sqrt(num / den) * 10^19) = floor(sqrt(floor(num * 10^38 / den)))
+func sqrtQuo(num, den udecimal.Decimal) (udecimal.Decimal, error) {
+	if den.IsZero() {
+		return udecimal.Decimal{}, udecimal.ErrDivideByZero
+	}
+
+	e19 := pow10(19)
+
+	q, _, err := num.Mul(e19).Mul(e19).QuoRem(den)
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	s, err := q.Sqrt()
+	if err != nil {
+		return udecimal.Decimal{}, err
+	}
+
+	return s.Trunc(0).Div(e19)
+}
+
+// scale returns xs multiplied by 10^p as integers, where p is the maximum precision of xs,
+// and the factor 10^p
+func scale(xs []udecimal.Decimal) ([]udecimal.Decimal, udecimal.Decimal) {
+	var p int
+	for _, x := range xs {
+		p = max(p, x.Prec())
+	}
+
+	factor := pow10(p)
+
+	ints := make([]udecimal.Decimal, len(xs))
+	for i, x := range xs {
+		ints[i] = x.Mul(factor).Trunc(0)
+	}
+
+	return ints, factor
+}
+
+func checkPair(xs, ys []udecimal.Decimal) error {
+	if len(xs) == 0 {
+		return ErrEmpty
+	}
+
+	if len(xs) != len(ys) {
+		return ErrLengthMismatch
+	}
+
+	return nil
+}
+
+func sortedCopy(xs []udecimal.Decimal) []udecimal.Decimal {
+	sorted := slices.Clone(xs)
+	slices.SortFunc(sorted, func(a, b udecimal.Decimal) int {
+		return a.Cmp(b)
+	})
+
+	return sorted
+}
+
+// pow10 returns 10^n, where 0 <= n <= 19
+func pow10(n int) udecimal.Decimal {
+	v := uint64(1)
+	for range n {
+		v *= 10
+	}
+
+	return udecimal.MustFromUint64(v, 0)
+}
+
+func fromInt(n int) udecimal.Decimal {
+	return udecimal.MustFromInt64(int64(n), 0)
+}
@@ -0,0 +1,236 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markovichecha/udecimal"
+)
+
+func parseAll(ss ...string) []udecimal.Decimal {
+	ds := make([]udecimal.Decimal, len(ss))
+	for i, s := range ss {
+		ds[i] = udecimal.MustParse(s)
+	}
+
+	return ds
+}
+
+func TestSumMeanVariance(t *testing.T) {
+	testcases := []struct {
+		xs                   []string
+		sum, mean, vari, std string
+		wantErr              error
+	}{
+		{nil, "0", "", "", "", ErrEmpty},
+		{[]string{"5"}, "5", "5", "", "", udecimal.ErrDivideByZero},
+		{[]string{"7", "7", "7"}, "21", "7", "0", "0", nil},
+		{[]string{"1", "2", "3", "4"}, "10", "2.5", "1.6666666666666666666", "1.2909944487358056283", nil},
+		{[]string{"1.1", "2.2", "3.3"}, "6.6", "2.2", "1.21", "1.1", nil},
+		{[]string{"2.5", "-1.25", "3.125", "0.0000000001", "10"}, "14.3750000001", "2.87500000002", "19.06249999985625", "4.3660622991267829594", nil},
+		{
+			[]string{"123456789012345678901234567890.5", "-98765432109876543210.123456789", "0.0000000000000000001"},
+			"123456788913580246791358024680.3765432110000000001",
+			"41152262971193415597119341560.1255144036666666667",
+			"5080526255144033291317380555007366774418228929603081339337.9078519613714626843",
+			"71277810398075734459666270536.4028053435066800397",
+			nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(strings.Join(tc.xs, ","), func(t *testing.T) {
+			xs := parseAll(tc.xs...)
+			require.Equal(t, tc.sum, Sum(xs).String())
+
+			mean, err := Mean(xs)
+			if tc.mean == "" {
+				require.Equal(t, ErrEmpty, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.mean, mean.String())
+			}
+
+			v, err := Variance(xs)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+
+				_, err = StdDev(xs)
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.vari, v.String())
+
+			s, err := StdDev(xs)
+			require.NoError(t, err)
+			require.Equal(t, tc.std, s.String())
+		})
+	}
+}
+
+func TestWeightedMean(t *testing.T) {
+	testcases := []struct {
+		xs, ws  []string
+		want    string
+		wantErr error
+	}{
+		{nil, nil, "", ErrEmpty},
+		{[]string{"1"}, []string{"1", "2"}, "", ErrLengthMismatch},
+		{[]string{"1", "2"}, []string{"1", "-1"}, "", udecimal.ErrDivideByZero},
+		{[]string{"1", "2"}, []string{"1", "2"}, "1.6666666666666666666", nil},
+		{[]string{"10.5", "20.25", "30"}, []string{"0.2", "0.3", "0.5"}, "23.175", nil},
+		{[]string{"0.0000000000000000001", "0.0000000000000000003"}, []string{"0.0000000000000000001", "0.0000000000000000001"}, "0.0000000000000000002", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%v_%v", tc.xs, tc.ws), func(t *testing.T) {
+			m, err := WeightedMean(parseAll(tc.xs...), parseAll(tc.ws...))
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, m.String())
+		})
+	}
+}
+
+func TestMedian(t *testing.T) {
+	testcases := []struct {
+		xs      []string
+		want    string
+		wantErr error
+	}{
+		{nil, "", ErrEmpty},
+		{[]string{"3"}, "3", nil},
+		{[]string{"3", "1", "2"}, "2", nil},
+		{[]string{"4", "1", "3", "2"}, "2.5", nil},
+		{[]string{"-1.5", "-2.5"}, "-2", nil},
+		{[]string{"0.0000000000000000001", "0.0000000000000000002"}, "0.0000000000000000001", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(strings.Join(tc.xs, ","), func(t *testing.T) {
+			xs := parseAll(tc.xs...)
+			orig := slicesString(xs)
+
+			m, err := Median(xs)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, m.String())
+
+			// make sure the input is not modified
+			require.Equal(t, orig, slicesString(xs))
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	data := []string{"50", "15", "40", "20", "35"}
+
+	testcases := []struct {
+		xs      []string
+		p       string
+		method  PercentileMethod
+		want    string
+		wantErr error
+	}{
+		{nil, "50", Linear, "", ErrEmpty},
+		{data, "-1", Linear, "", ErrInvalidPercentile},
+		{data, "100.1", Linear, "", ErrInvalidPercentile},
+		{data, "50", PercentileMethod(5), "", ErrInvalidMethod},
+		{data, "0", Linear, "15", nil},
+		{data, "100", Linear, "50", nil},
+		{data, "50", Linear, "35", nil},
+		{data, "50", Nearest, "35", nil},
+		{data, "40", Linear, "29", nil},
+		{data, "40", Lower, "20", nil},
+		{data, "40", Higher, "35", nil},
+		{data, "40", Nearest, "35", nil},
+		{data, "40", Midpoint, "27.5", nil},
+		{data, "12.5", Linear, "17.5", nil},
+		{data, "12.5", Nearest, "15", nil},
+		{data, "37.5", Nearest, "35", nil},
+		{data, "37.5", Midpoint, "27.5", nil},
+		{[]string{"1", "2", "4"}, "33.3333", Linear, "1.666666", nil},
+		{[]string{"-3", "-1"}, "75", Linear, "-1.5", nil},
+		{[]string{"7"}, "99", Linear, "7", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%v_%s_%d", tc.xs, tc.p, tc.method), func(t *testing.T) {
+			got, err := Percentile(parseAll(tc.xs...), udecimal.MustParse(tc.p), tc.method)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got.String())
+		})
+	}
+}
+
+func TestCovarianceCorrelation(t *testing.T) {
+	testcases := []struct {
+		xs, ys    []string
+		cov, corr string
+		wantErr   error
+	}{
+		{nil, nil, "", "", ErrEmpty},
+		{[]string{"1", "2"}, []string{"1"}, "", "", ErrLengthMismatch},
+		{[]string{"1", "2", "3", "4", "5"}, []string{"2", "4", "5", "4", "5"}, "1.5", "0.774596669241483377", nil},
+		{[]string{"1", "2", "3", "4", "5"}, []string{"-2", "-4", "-5", "-4", "-5"}, "-1.5", "-0.774596669241483377", nil},
+		{[]string{"1.5", "2.25", "-0.125"}, []string{"0.3", "0.7", "1.1"}, "-0.325", "-0.6692382674782157256", nil},
+		{[]string{"1", "2", "3"}, []string{"2", "4", "6"}, "2", "1", nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%v_%v", tc.xs, tc.ys), func(t *testing.T) {
+			xs := parseAll(tc.xs...)
+			ys := parseAll(tc.ys...)
+
+			cov, err := Covariance(xs, ys)
+			if tc.wantErr != nil {
+				require.Equal(t, tc.wantErr, err)
+
+				_, err = Correlation(xs, ys)
+				require.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.cov, cov.String())
+
+			corr, err := Correlation(xs, ys)
+			require.NoError(t, err)
+			require.Equal(t, tc.corr, corr.String())
+		})
+	}
+
+	// single value or zero variance
+	_, err := Covariance(parseAll("1"), parseAll("2"))
+	require.Equal(t, udecimal.ErrDivideByZero, err)
+
+	_, err = Correlation(parseAll("1", "1"), parseAll("1", "2"))
+	require.Equal(t, udecimal.ErrDivideByZero, err)
+}
+
+func slicesString(xs []udecimal.Decimal) string {
+	ss := make([]string, len(xs))
+	for i, x := range xs {
+		ss[i] = x.String()
+	}
+
+	return strings.Join(ss, ",")
+}
@@ -49,6 +49,15 @@ func (u u128) Cmp(v u128) int {
 	}
 }
 
+// bitLen returns the number of bits required to represent u
+func (u u128) bitLen() int {
+	if u.hi != 0 {
+		return 64 + bits.Len64(u.hi)
+	}
+
+	return bits.Len64(u.lo)
+}
+
 func (u u128) Cmp64(v uint64) int {
 	if u.hi != 0 {
 		return 1
@@ -1,6 +1,8 @@
 package udecimal
 
 import (
+	"encoding/binary"
+	"math/big"
 	"math/bits"
 )
 
@@ -54,6 +56,58 @@ func (u u256) bitLen() int {
 // 	return s
 // }
 
+// rsh returns u>>n.
+func (u u256) rsh(n uint) u256 {
+	words := [4]uint64{u.lo, u.hi, u.carry.lo, u.carry.hi}
+
+	var r [4]uint64
+	shift, offset := n%64, int(n/64) //nolint:gosec // n/64 is small, so it's safe to convert to int
+	for i := 0; i+offset < 4; i++ {
+		r[i] = words[i+offset] >> shift
+		if shift != 0 && i+offset+1 < 4 {
+			r[i] |= words[i+offset+1] << (64 - shift)
+		}
+	}
+
+	return u256{lo: r[0], hi: r[1], carry: u128{lo: r[2], hi: r[3]}}
+}
+
+// add returns u+v (mod 2^256).
+func (u u256) add(v u256) u256 {
+	lo, c := bits.Add64(u.lo, v.lo, 0)
+	hi, c := bits.Add64(u.hi, v.hi, c)
+	clo, c := bits.Add64(u.carry.lo, v.carry.lo, c)
+	chi, _ := bits.Add64(u.carry.hi, v.carry.hi, c)
+
+	return u256{lo: lo, hi: hi, carry: u128{lo: clo, hi: chi}}
+}
+
+// sub returns u-v (mod 2^256).
+func (u u256) sub(v u256) u256 {
+	lo, b := bits.Sub64(u.lo, v.lo, 0)
+	hi, b := bits.Sub64(u.hi, v.hi, b)
+	clo, b := bits.Sub64(u.carry.lo, v.carry.lo, b)
+	chi, _ := bits.Sub64(u.carry.hi, v.carry.hi, b)
+
+	return u256{lo: lo, hi: hi, carry: u128{lo: clo, hi: chi}}
+}
+
+// isNeg reports whether u is negative when it's interpreted as a two's complement signed integer.
+func (u u256) isNeg() bool {
+	return u.carry.hi>>63 != 0
+}
+
+// ToBigInt converts u to *big.Int
+func (u u256) ToBigInt() *big.Int {
+	bytes := make([]byte, 32)
+	binary.BigEndian.PutUint64(bytes, u.carry.hi)
+	binary.BigEndian.PutUint64(bytes[8:], u.carry.lo)
+	binary.BigEndian.PutUint64(bytes[16:], u.hi)
+	binary.BigEndian.PutUint64(bytes[24:], u.lo)
+
+	return new(big.Int).SetBytes(bytes)
+}
+
 // Compare u256 and U128, returns:
 //
 //	+1 when u > v
@@ -0,0 +1,117 @@
+package words
+
+import "strings"
+
+var (
+	deOnes = [20]string{
+		"null", "eins", "zwei", "drei", "vier", "fünf", "sechs", "sieben", "acht", "neun",
+		"zehn", "elf", "zwölf", "dreizehn", "vierzehn", "fünfzehn", "sechzehn", "siebzehn", "achtzehn", "neunzehn",
+	}
+
+	deTens = [10]string{"", "", "zwanzig", "dreißig", "vierzig", "fünfzig", "sechzig", "siebzig", "achtzig", "neunzig"}
+
+	// singular and plural of the scales from 10^6, they are feminine nouns
+	deScales = [maxGroups][2]string{
+		2:  {"Million", "Millionen"},
+		3:  {"Milliarde", "Milliarden"},
+		4:  {"Billion", "Billionen"},
+		5:  {"Billiarde", "Billiarden"},
+		6:  {"Trillion", "Trillionen"},
+		7:  {"Trilliarde", "Trilliarden"},
+		8:  {"Quadrillion", "Quadrillionen"},
+		9:  {"Quadrilliarde", "Quadrilliarden"},
+		10: {"Quintillion", "Quintillionen"},
+		11: {"Quintilliarde", "Quintilliarden"},
+		12: {"Sextillion", "Sextillionen"},
+	}
+)
+
+type german struct{}
+
+// cardinal writes the number below one million as one word, the millions and above as separate words,
+// e.g. zwei Millionen dreihunderttausendeins
+func (german) cardinal(g []int, f form) string {
+	if isZero(g) {
+		return deOnes[0]
+	}
+
+	words := make([]string, 0, len(g))
+	for i := len(g) - 1; i >= 2; i-- {
+		if g[i] == 0 {
+			continue
+		}
+
+		if g[i] == 1 {
+			words = append(words, "eine "+deScales[i][0])
+		} else {
+			words = append(words, deBelow1000(g[i], "eine")+" "+deScales[i][1])
+		}
+	}
+
+	var low string
+	if t := group(g, 1); t > 0 {
+		low = deBelow1000(t, "ein") + "tausend"
+	}
+
+	if u := group(g, 0); u > 0 {
+		one := "eins"
+		switch f {
+		case masculine:
+			one = "ein"
+		case feminine:
+			one = "eine"
+		}
+
+		low += deBelow1000(u, one)
+	}
+
+	if low != "" {
+		words = append(words, low)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// deBelow1000 writes 0 < n < 1000 as one word, one is the word for a final 1, e.g. "eins" in hunderteins
+func deBelow1000(n int, one string) string {
+	h, r := n/100, n%100
+
+	var s string
+	if h > 0 {
+		s = deCompound(h) + "hundert"
+	}
+
+	switch {
+	case r == 1:
+		s += one
+	case r > 0 && r < 20:
+		s += deOnes[r]
+	case r > 0 && r%10 == 0:
+		s += deTens[r/10]
+	case r > 0:
+		s += deCompound(r%10) + "und" + deTens[r/10]
+	}
+
+	return s
+}
+
+// deCompound writes the digit 0 < n < 10 inside a word, e.g. "ein" in einhundert
+func deCompound(n int) string {
+	if n == 1 {
+		return "ein"
+	}
+
+	return deOnes[n]
+}
+
+func (german) singular(g []int) bool {
+	return isOne(g)
+}
+
+func (german) unitPrefix([]int, string) string {
+	return ""
+}
+
+func (german) minus() string { return "minus" }
+func (german) point() string { return "Komma" }
+func (german) and() string   { return "und" }
@@ -0,0 +1,38 @@
+package words
+
+import (
+	"fmt"
+
+	"github.com/markovichecha/udecimal"
+)
+
+func ExampleSpell() {
+	dollar := Unit{Singular: "dollar", Plural: "dollars", MinorSingular: "cent", MinorPlural: "cents", MinorDigits: 2}
+	amount := udecimal.MustParse("1234.56")
+
+	fmt.Println(Spell(amount, Options{Unit: dollar, Fraction: FractionNumeric, Capitalize: true}))
+	fmt.Println(Spell(amount, Options{Unit: dollar}))
+	fmt.Println(Spell(udecimal.MustParse("1.234"), Options{Unit: dollar}))
+	// Output:
+	// One thousand two hundred thirty-four and 56/100 dollars <nil>
+	// one thousand two hundred thirty-four dollars and fifty-six cents <nil>
+	//  words: amount has more digits after the decimal point than the minor unit: 3 digits, the minor unit has 2
+}
+
+func ExampleSpell_languages() {
+	amount := udecimal.MustParse("2021.50")
+
+	fr := Unit{Singular: "euro", Plural: "euros", MinorSingular: "centime", MinorPlural: "centimes", MinorDigits: 2}
+	de := Unit{Singular: "Euro", Plural: "Euro", MinorSingular: "Cent", MinorPlural: "Cent", MinorDigits: 2}
+	es := Unit{Singular: "euro", Plural: "euros", MinorSingular: "céntimo", MinorPlural: "céntimos", MinorDigits: 2}
+
+	fmt.Println(Spell(amount, Options{Language: French, Unit: fr}))
+	fmt.Println(Spell(amount, Options{Language: German, Unit: de}))
+	fmt.Println(Spell(amount, Options{Language: Spanish, Unit: es}))
+	fmt.Println(Spell(udecimal.MustParse("3.14"), Options{}))
+	// Output:
+	// deux mille vingt et un euros et cinquante centimes <nil>
+	// zweitausendeinundzwanzig Euro und fünfzig Cent <nil>
+	// dos mil veintiún euros con cincuenta céntimos <nil>
+	// three point one four <nil>
+}
@@ -0,0 +1,77 @@
+package words
+
+import "strings"
+
+var (
+	enOnes = [20]string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+
+	enTens = [10]string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+
+	enScales = [maxGroups]string{
+		"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion", "sextillion",
+		"septillion", "octillion", "nonillion", "decillion", "undecillion",
+	}
+)
+
+type english struct{}
+
+func (english) cardinal(g []int, _ form) string {
+	if isZero(g) {
+		return enOnes[0]
+	}
+
+	words := make([]string, 0, 2*len(g))
+	for i := len(g) - 1; i >= 0; i-- {
+		if g[i] == 0 {
+			continue
+		}
+
+		words = append(words, enBelow1000(g[i]))
+		if i > 0 {
+			words = append(words, enScales[i])
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// enBelow1000 writes 0 < n < 1000
+func enBelow1000(n int) string {
+	h, r := n/100, n%100
+
+	var s string
+	if h > 0 {
+		s = enOnes[h] + " hundred"
+		if r == 0 {
+			return s
+		}
+
+		s += " "
+	}
+
+	switch {
+	case r < 20:
+		s += enOnes[r]
+	case r%10 == 0:
+		s += enTens[r/10]
+	default:
+		s += enTens[r/10] + "-" + enOnes[r%10]
+	}
+
+	return s
+}
+
+func (english) singular(g []int) bool {
+	return isOne(g)
+}
+
+func (english) unitPrefix([]int, string) string {
+	return ""
+}
+
+func (english) minus() string { return "minus" }
+func (english) point() string { return "point" }
+func (english) and() string   { return "and" }
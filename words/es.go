@@ -0,0 +1,157 @@
+package words
+
+import "strings"
+
+var (
+	esOnes = [30]string{
+		"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve",
+		"diez", "once", "doce", "trece", "catorce", "quince", "dieciséis", "diecisiete", "dieciocho", "diecinueve",
+		"veinte", "veintiuno", "veintidós", "veintitrés", "veinticuatro", "veinticinco", "veintiséis", "veintisiete", "veintiocho", "veintinueve",
+	}
+
+	esTens = [10]string{"", "", "", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa"}
+
+	esHundreds = [10]string{
+		"", "ciento", "doscientos", "trescientos", "cuatrocientos", "quinientos", "seiscientos", "setecientos", "ochocientos", "novecientos",
+	}
+
+	// singular and plural of the scales of 10^(6k), k >= 1
+	esScales = [7][2]string{
+		1: {"millón", "millones"},
+		2: {"billón", "billones"},
+		3: {"trillón", "trillones"},
+		4: {"cuatrillón", "cuatrillones"},
+		5: {"quintillón", "quintillones"},
+		6: {"sextillón", "sextillones"},
+	}
+)
+
+type spanish struct{}
+
+// cardinal writes the number in groups of 6 digits, e.g. mil doscientos millones
+func (spanish) cardinal(g []int, f form) string {
+	if isZero(g) {
+		return esOnes[0]
+	}
+
+	words := make([]string, 0, len(g))
+	for k := (len(g) - 1) / 2; k >= 1; k-- {
+		hi, lo := group(g, 2*k+1), group(g, 2*k)
+
+		switch {
+		case hi == 0 && lo == 0:
+			continue
+		case hi == 0 && lo == 1:
+			words = append(words, "un "+esScales[k][0])
+		default:
+			// millón is masculine: veintiún millones
+			words = append(words, esBelowMillion(hi, lo, masculine)+" "+esScales[k][1])
+		}
+	}
+
+	if hi, lo := group(g, 1), group(g, 0); hi != 0 || lo != 0 {
+		words = append(words, esBelowMillion(hi, lo, f))
+	}
+
+	return strings.Join(words, " ")
+}
+
+// esBelowMillion writes thousands*1000 + n > 0
+func esBelowMillion(thousands, n int, f form) string {
+	var s string
+
+	switch {
+	case thousands == 1:
+		s = "mil"
+	case thousands > 1:
+		// mil is not a noun, a final uno before it is apocopated even when the hundreds are feminine:
+		// veintiún mil, doscientas un mil libras
+		s = esBelow1000(thousands, masculine) + " mil"
+		if f == feminine {
+			s = strings.Replace(s, "ientos", "ientas", 1)
+		}
+	}
+
+	if n == 0 {
+		return s
+	}
+
+	if s != "" {
+		s += " "
+	}
+
+	return s + esBelow1000(n, f)
+}
+
+// esBelow1000 writes 0 < n < 1000
+func esBelow1000(n int, f form) string {
+	h, r := n/100, n%100
+
+	var s string
+	switch {
+	case h == 1 && r == 0:
+		return "cien"
+	case h > 0 && f == feminine:
+		s = strings.Replace(esHundreds[h], "ientos", "ientas", 1)
+	case h > 0:
+		s = esHundreds[h]
+	}
+
+	if r == 0 {
+		return s
+	}
+
+	if s != "" {
+		s += " "
+	}
+
+	return s + esBelow100(r, f)
+}
+
+// esBelow100 writes 0 < n < 100, a final uno depends on the form: uno, un dólar, una libra
+func esBelow100(n int, f form) string {
+	switch {
+	case n == 1:
+		return esOne(f, "uno", "un", "una")
+	case n == 21:
+		return esOne(f, "veintiuno", "veintiún", "veintiuna")
+	case n < 30:
+		return esOnes[n]
+	case n%10 == 0:
+		return esTens[n/10]
+	case n%10 == 1:
+		return esTens[n/10] + " y " + esOne(f, "uno", "un", "una")
+	default:
+		return esTens[n/10] + " y " + esOnes[n%10]
+	}
+}
+
+// esOne returns the word for the form f
+func esOne(f form, alone, masc, fem string) string {
+	switch f {
+	case masculine:
+		return masc
+	case feminine:
+		return fem
+	default:
+		return alone
+	}
+}
+
+// singular reports whether g is 1: un dólar, cero dólares
+func (spanish) singular(g []int) bool {
+	return isOne(g)
+}
+
+// unitPrefix returns "de " when the number ends with a scale noun: un millón de dólares
+func (spanish) unitPrefix(g []int, _ string) string {
+	if group(g, 0) != 0 || group(g, 1) != 0 || isZero(g) {
+		return ""
+	}
+
+	return "de "
+}
+
+func (spanish) minus() string { return "menos" }
+func (spanish) point() string { return "coma" }
+func (spanish) and() string   { return "con" }
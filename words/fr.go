@@ -0,0 +1,146 @@
+package words
+
+import "strings"
+
+var (
+	frOnes = [17]string{
+		"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf",
+		"dix", "onze", "douze", "treize", "quatorze", "quinze", "seize",
+	}
+
+	frTens = [7]string{"", "dix", "vingt", "trente", "quarante", "cinquante", "soixante"}
+
+	// scales from 10^6, the plural adds an s
+	frScales = [maxGroups]string{
+		2: "million", 3: "milliard", 4: "billion", 5: "billiard", 6: "trillion", 7: "trilliard",
+		8: "quadrillion", 9: "quadrilliard", 10: "quintillion", 11: "quintilliard", 12: "sextillion",
+	}
+)
+
+type french struct{}
+
+func (french) cardinal(g []int, f form) string {
+	if isZero(g) {
+		return frOnes[0]
+	}
+
+	words := make([]string, 0, len(g))
+	for i := len(g) - 1; i >= 2; i-- {
+		if g[i] == 0 {
+			continue
+		}
+
+		if g[i] == 1 {
+			words = append(words, "un "+frScales[i])
+		} else {
+			// million is a noun, so cents and quatre-vingts keep their s: deux cents millions
+			words = append(words, frBelow1000(g[i], true)+" "+frScales[i]+"s")
+		}
+	}
+
+	switch t := group(g, 1); {
+	case t == 1:
+		words = append(words, "mille")
+	case t > 1:
+		// mille is not a noun: deux cent mille
+		words = append(words, frBelow1000(t, false)+" mille")
+	}
+
+	if u := group(g, 0); u > 0 {
+		s := frBelow1000(u, true)
+		if f == feminine && strings.HasSuffix(s, "un") {
+			s += "e"
+		}
+
+		words = append(words, s)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// frBelow1000 writes 0 < n < 1000. With plural, the final cent and quatre-vingt take an s: deux cents, quatre-vingts.
+func frBelow1000(n int, plural bool) string {
+	h, r := n/100, n%100
+
+	var s string
+	switch {
+	case h == 1:
+		s = "cent"
+	case h > 1:
+		s = frOnes[h] + " cent"
+		if r == 0 && plural {
+			s += "s"
+		}
+	}
+
+	if r == 0 {
+		return s
+	}
+
+	if s != "" {
+		s += " "
+	}
+
+	return s + frBelow100(r, plural)
+}
+
+// frBelow100 writes 0 < n < 100
+func frBelow100(n int, plural bool) string {
+	t, u := n/10, n%10
+
+	switch {
+	case n <= 16:
+		return frOnes[n]
+	case n < 20:
+		return "dix-" + frOnes[u]
+	case n < 70 && u == 0:
+		return frTens[t]
+	case n < 70 && u == 1:
+		return frTens[t] + " et un"
+	case n < 70:
+		return frTens[t] + "-" + frOnes[u]
+	case n == 71:
+		return "soixante et onze"
+	case n < 80:
+		return "soixante-" + frBelow100(n-60, plural)
+	case n == 80 && plural:
+		return "quatre-vingts"
+	case n == 80:
+		return "quatre-vingt"
+	default:
+		// 81 is quatre-vingt-un, without et
+		return "quatre-vingt-" + frBelow100(n-80, plural)
+	}
+}
+
+// singular reports whether g is 0 or 1, in French the unit is singular below 2: zéro euro, un euro
+func (french) singular(g []int) bool {
+	return isZero(g) || isOne(g)
+}
+
+// unitPrefix returns "de " or "d'" when the number ends with a scale noun: un million d'euros
+func (french) unitPrefix(g []int, unit string) string {
+	if group(g, 0) != 0 || group(g, 1) != 0 || isZero(g) {
+		return ""
+	}
+
+	if startsWithVowel(unit) {
+		return "d'"
+	}
+
+	return "de "
+}
+
+func (french) minus() string { return "moins" }
+func (french) point() string { return "virgule" }
+func (french) and() string   { return "et" }
+
+func startsWithVowel(s string) bool {
+	for _, v := range []string{"a", "e", "i", "o", "u", "é", "è", "ê", "â", "î", "ô", "û"} {
+		if strings.HasPrefix(strings.ToLower(s), v) {
+			return true
+		}
+	}
+
+	return false
+}
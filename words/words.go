@@ -0,0 +1,342 @@
+// Package words spells out [udecimal.Decimal] values in words, e.g. for the amount line of cheques
+// and legal documents: "one thousand two hundred thirty-four and 56/100 dollars".
+//
+// English, French, German and Spanish are supported. The integer part can be up to 39 digits,
+// which covers every integer coefficient that fits into 128 bits. The scales are the short scale
+// in English (million, billion, ...) and the long scale in the other languages (million, milliard, ...).
+package words
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/markovichecha/udecimal"
+)
+
+var (
+	// ErrOutOfRange is returned when the integer part has more than 39 digits
+	ErrOutOfRange = fmt.Errorf("words: integer part must have at most %d digits", 3*maxGroups)
+
+	// ErrTooManyDigits is returned when the amount has more digits after the decimal point than the minor unit
+	ErrTooManyDigits = fmt.Errorf("words: amount has more digits after the decimal point than the minor unit")
+
+	// ErrInvalidLanguage is returned when the language is unknown
+	ErrInvalidLanguage = fmt.Errorf("words: invalid language")
+
+	// ErrInvalidFractionStyle is returned when the fraction style is unknown
+	ErrInvalidFractionStyle = fmt.Errorf("words: invalid fraction style")
+)
+
+// maxGroups is the maximum number of groups of 3 digits of the integer part, up to 10^36 (undecillion)
+const maxGroups = 13
+
+// Language is the language of the words
+type Language uint8
+
+const (
+	// English uses American conventions: no "and" after hundred and the short scale
+	English Language = iota
+
+	// French uses the traditional spelling: hyphens only below one hundred, e.g. deux cent vingt-trois
+	French
+
+	// German writes numbers below one million as one word, e.g. eintausendzweihundertvierunddreißig
+	German
+
+	// Spanish uses the long scale with mil millones for 10^9
+	Spanish
+)
+
+// String returns the English name of the language
+func (l Language) String() string {
+	switch l {
+	case English:
+		return "English"
+	case French:
+		return "French"
+	case German:
+		return "German"
+	case Spanish:
+		return "Spanish"
+	default:
+		return fmt.Sprintf("Language(%d)", l)
+	}
+}
+
+// FractionStyle is how the digits after the decimal point are written
+type FractionStyle uint8
+
+const (
+	// FractionWords spells out the fraction: the minor units with a currency unit, e.g. "and fifty-six cents",
+	// the digits one by one otherwise, e.g. "point five six"
+	FractionWords FractionStyle = iota
+
+	// FractionNumeric writes the fraction as digits over a power of 10, as on cheques, e.g. "and 56/100 dollars".
+	// With a currency unit the denominator is 10^MinorDigits and a zero fraction is written as 00/100.
+	FractionNumeric
+)
+
+// Unit is a currency unit with its minor unit, e.g. dollar and cent.
+// The names are written as they are, so they must be in the language of the words.
+type Unit struct {
+	// Singular and Plural are the names of the unit, e.g. "dollar" and "dollars"
+	Singular, Plural string
+
+	// MinorSingular and MinorPlural are the names of the minor unit, e.g. "cent" and "cents".
+	// If they are empty, the fraction is written with [FractionNumeric].
+	MinorSingular, MinorPlural string
+
+	// MinorDigits is the number of digits of the minor unit, e.g. 2 for cents and 0 for yen
+	MinorDigits uint8
+
+	// Feminine and MinorFeminine select the feminine form of the numbers before the unit names,
+	// e.g. "una libra" instead of "un libra". They are ignored in English.
+	Feminine, MinorFeminine bool
+}
+
+// IsZero reports whether u is the zero value, in which case the number is written without unit
+func (u Unit) IsZero() bool {
+	return u == Unit{}
+}
+
+// Options configures [Spell]. The zero value writes English words without unit,
+// with the fraction spelled digit by digit.
+type Options struct {
+	Language Language
+	Unit     Unit
+	Fraction FractionStyle
+
+	// Capitalize writes the first letter in upper case
+	Capitalize bool
+}
+
+// form is the form of a number: alone or before a masculine or feminine noun
+type form uint8
+
+const (
+	standalone form = iota
+	masculine
+	feminine
+)
+
+func nounForm(fem bool) form {
+	if fem {
+		return feminine
+	}
+
+	return masculine
+}
+
+// speller writes numbers in a language
+type speller interface {
+	// cardinal writes the integer with the groups of 3 digits g, g[0] is the least significant group
+	cardinal(g []int, f form) string
+
+	// singular reports whether a unit after the integer g is singular
+	singular(g []int) bool
+
+	// unitPrefix returns the word between the number g and a unit, e.g. "de " in "un million de dollars"
+	unitPrefix(g []int, unit string) string
+
+	// words for the minus sign, the decimal point and the conjunction of the fraction
+	minus() string
+	point() string
+	and() string
+}
+
+func spellerOf(l Language) (speller, error) {
+	switch l {
+	case English:
+		return english{}, nil
+	case French:
+		return french{}, nil
+	case German:
+		return german{}, nil
+	case Spanish:
+		return spanish{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidLanguage, l)
+	}
+}
+
+// Spell returns d in words.
+//
+// Without unit, the fraction is spelled digit by digit ("one point two five") or written as digits over
+// a power of 10 ("one and 25/100"), the trailing zeros are not written.
+// With a unit, the fraction is written in minor units and must not have more digits than the minor unit.
+//
+// Returns error if:
+//   - the language or the fraction style is unknown ([ErrInvalidLanguage], [ErrInvalidFractionStyle])
+//   - the integer part has more than 39 digits ([ErrOutOfRange])
+//   - the fraction has more digits than the minor unit ([ErrTooManyDigits])
+//
+// Example:
+//
+//	Spell(1234.56, Options{Unit: dollar, Fraction: FractionNumeric, Capitalize: true})
+//	-> One thousand two hundred thirty-four and 56/100 dollars
+//	Spell(1234.56, Options{Language: German, Unit: euro})
+//	-> eintausendzweihundertvierunddreißig Euro und sechsundfünfzig Cent
+func Spell(d udecimal.Decimal, opts Options) (string, error) {
+	sp, err := spellerOf(opts.Language)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.Fraction > FractionNumeric {
+		return "", fmt.Errorf("%w: %d", ErrInvalidFractionStyle, opts.Fraction)
+	}
+
+	intPart, frac, _ := strings.Cut(d.Abs().String(), ".")
+
+	g, err := groups(intPart)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if d.IsNeg() {
+		sb.WriteString(sp.minus())
+		sb.WriteByte(' ')
+	}
+
+	if opts.Unit.IsZero() {
+		writeNumber(&sb, sp, g, frac, opts.Fraction)
+	} else if err := writeAmount(&sb, sp, g, frac, opts.Unit, opts.Fraction); err != nil {
+		return "", err
+	}
+
+	s := sb.String()
+	if opts.Capitalize {
+		r, size := utf8.DecodeRuneInString(s)
+		s = string(unicode.ToUpper(r)) + s[size:]
+	}
+
+	return s, nil
+}
+
+// writeNumber writes a number without unit
+func writeNumber(sb *strings.Builder, sp speller, g []int, frac string, style FractionStyle) {
+	sb.WriteString(sp.cardinal(g, standalone))
+
+	if frac == "" {
+		return
+	}
+
+	if style == FractionNumeric {
+		writeNumeric(sb, sp, frac, len(frac))
+		return
+	}
+
+	sb.WriteByte(' ')
+	sb.WriteString(sp.point())
+
+	for _, c := range frac {
+		sb.WriteByte(' ')
+		sb.WriteString(sp.cardinal([]int{int(c - '0')}, standalone))
+	}
+}
+
+// writeAmount writes a number with a currency unit
+func writeAmount(sb *strings.Builder, sp speller, g []int, frac string, u Unit, style FractionStyle) error {
+	if len(frac) > int(u.MinorDigits) {
+		return fmt.Errorf("%w: %d digits, the minor unit has %d", ErrTooManyDigits, len(frac), u.MinorDigits)
+	}
+
+	if u.MinorDigits == 0 {
+		writeUnit(sb, sp, g, u.Singular, u.Plural, u.Feminine)
+		return nil
+	}
+
+	if style == FractionNumeric || u.MinorSingular == "" && u.MinorPlural == "" {
+		// the unit is written after the fraction: one thousand and 56/100 dollars
+		sb.WriteString(sp.cardinal(g, standalone))
+		writeNumeric(sb, sp, frac, int(u.MinorDigits))
+		sb.WriteByte(' ')
+		sb.WriteString(u.Plural)
+
+		return nil
+	}
+
+	writeUnit(sb, sp, g, u.Singular, u.Plural, u.Feminine)
+
+	minor, _ := groups(frac + strings.Repeat("0", int(u.MinorDigits)-len(frac)))
+	if isZero(minor) {
+		return nil
+	}
+
+	sb.WriteByte(' ')
+	sb.WriteString(sp.and())
+	sb.WriteByte(' ')
+	writeUnit(sb, sp, minor, u.MinorSingular, u.MinorPlural, u.MinorFeminine)
+
+	return nil
+}
+
+// writeUnit writes the number g followed by the singular or plural name of a unit
+func writeUnit(sb *strings.Builder, sp speller, g []int, singular, plural string, fem bool) {
+	name := plural
+	if sp.singular(g) {
+		name = singular
+	}
+
+	sb.WriteString(sp.cardinal(g, nounForm(fem)))
+	sb.WriteByte(' ')
+	sb.WriteString(sp.unitPrefix(g, name))
+	sb.WriteString(name)
+}
+
+// writeNumeric writes the fraction as " and frac/10^digits", frac is padded with zeros to digits
+func writeNumeric(sb *strings.Builder, sp speller, frac string, digits int) {
+	sb.WriteByte(' ')
+	sb.WriteString(sp.and())
+	sb.WriteByte(' ')
+	sb.WriteString(frac)
+	sb.WriteString(strings.Repeat("0", digits-len(frac)))
+	sb.WriteString("/1")
+	sb.WriteString(strings.Repeat("0", digits))
+}
+
+// groups splits the digits into groups of 3 digits, the least significant group first
+func groups(digits string) ([]int, error) {
+	if len(digits) > 3*maxGroups {
+		return nil, fmt.Errorf("%w: %d digits", ErrOutOfRange, len(digits))
+	}
+
+	g := make([]int, 0, maxGroups)
+	for end := len(digits); end > 0; end -= 3 {
+		var n int
+		for _, c := range digits[max(end-3, 0):end] {
+			n = n*10 + int(c-'0')
+		}
+
+		g = append(g, n)
+	}
+
+	return g, nil
+}
+
+func isZero(g []int) bool {
+	for _, n := range g {
+		if n != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isOne reports whether g is the number 1
+func isOne(g []int) bool {
+	return len(g) > 0 && g[0] == 1 && isZero(g[1:])
+}
+
+// group returns g[i], or 0 if g has no such group
+func group(g []int, i int) int {
+	if i < len(g) {
+		return g[i]
+	}
+
+	return 0
+}
@@ -0,0 +1,204 @@
+package words
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/markovichecha/udecimal"
+)
+
+func TestCardinal(t *testing.T) {
+	testcases := []struct {
+		in             string
+		en, fr, de, es string
+	}{
+		{"0", "zero", "zéro", "null", "cero"},
+		{"1", "one", "un", "eins", "uno"},
+		{"11", "eleven", "onze", "elf", "once"},
+		{"16", "sixteen", "seize", "sechzehn", "dieciséis"},
+		{"17", "seventeen", "dix-sept", "siebzehn", "diecisiete"},
+		{"21", "twenty-one", "vingt et un", "einundzwanzig", "veintiuno"},
+		{"22", "twenty-two", "vingt-deux", "zweiundzwanzig", "veintidós"},
+		{"30", "thirty", "trente", "dreißig", "treinta"},
+		{"61", "sixty-one", "soixante et un", "einundsechzig", "sesenta y uno"},
+		{"70", "seventy", "soixante-dix", "siebzig", "setenta"},
+		{"71", "seventy-one", "soixante et onze", "einundsiebzig", "setenta y uno"},
+		{"77", "seventy-seven", "soixante-dix-sept", "siebenundsiebzig", "setenta y siete"},
+		{"80", "eighty", "quatre-vingts", "achtzig", "ochenta"},
+		{"81", "eighty-one", "quatre-vingt-un", "einundachtzig", "ochenta y uno"},
+		{"91", "ninety-one", "quatre-vingt-onze", "einundneunzig", "noventa y uno"},
+		{"99", "ninety-nine", "quatre-vingt-dix-neuf", "neunundneunzig", "noventa y nueve"},
+		{"100", "one hundred", "cent", "einhundert", "cien"},
+		{"101", "one hundred one", "cent un", "einhunderteins", "ciento uno"},
+		{"200", "two hundred", "deux cents", "zweihundert", "doscientos"},
+		{"280", "two hundred eighty", "deux cent quatre-vingts", "zweihundertachtzig", "doscientos ochenta"},
+		{"500", "five hundred", "cinq cents", "fünfhundert", "quinientos"},
+		{"1000", "one thousand", "mille", "eintausend", "mil"},
+		{"1001", "one thousand one", "mille un", "eintausendeins", "mil uno"},
+		{"21000", "twenty-one thousand", "vingt et un mille", "einundzwanzigtausend", "veintiún mil"},
+		{"80000", "eighty thousand", "quatre-vingt mille", "achtzigtausend", "ochenta mil"},
+		{"101000", "one hundred one thousand", "cent un mille", "einhunderteintausend", "ciento un mil"},
+		{"200000", "two hundred thousand", "deux cent mille", "zweihunderttausend", "doscientos mil"},
+		{"1000000", "one million", "un million", "eine Million", "un millón"},
+		{"1000001", "one million one", "un million un", "eine Million eins", "un millón uno"},
+		{"21000000", "twenty-one million", "vingt et un millions", "einundzwanzig Millionen", "veintiún millones"},
+		{"80000000", "eighty million", "quatre-vingts millions", "achtzig Millionen", "ochenta millones"},
+		{"200000000", "two hundred million", "deux cents millions", "zweihundert Millionen", "doscientos millones"},
+		{"1000000000", "one billion", "un milliard", "eine Milliarde", "mil millones"},
+		{"1001000000", "one billion one million", "un milliard un million", "eine Milliarde eine Million", "mil un millones"},
+		{"1000000000000", "one trillion", "un billion", "eine Billion", "un billón"},
+		{
+			"1234567891",
+			"one billion two hundred thirty-four million five hundred sixty-seven thousand eight hundred ninety-one",
+			"un milliard deux cent trente-quatre millions cinq cent soixante-sept mille huit cent quatre-vingt-onze",
+			"eine Milliarde zweihundertvierunddreißig Millionen fünfhundertsiebenundsechzigtausendachthunderteinundneunzig",
+			"mil doscientos treinta y cuatro millones quinientos sesenta y siete mil ochocientos noventa y uno",
+		},
+		{
+			// max u128
+			"340282366920938463463374607431768211455",
+			"three hundred forty undecillion two hundred eighty-two decillion three hundred sixty-six nonillion " +
+				"nine hundred twenty octillion nine hundred thirty-eight septillion four hundred sixty-three sextillion " +
+				"four hundred sixty-three quintillion three hundred seventy-four quadrillion six hundred seven trillion " +
+				"four hundred thirty-one billion seven hundred sixty-eight million two hundred eleven thousand four hundred fifty-five",
+			"trois cent quarante sextillions deux cent quatre-vingt-deux quintilliards trois cent soixante-six quintillions " +
+				"neuf cent vingt quadrilliards neuf cent trente-huit quadrillions quatre cent soixante-trois trilliards " +
+				"quatre cent soixante-trois trillions trois cent soixante-quatorze billiards six cent sept billions " +
+				"quatre cent trente et un milliards sept cent soixante-huit millions deux cent onze mille quatre cent cinquante-cinq",
+			"dreihundertvierzig Sextillionen zweihundertzweiundachtzig Quintilliarden dreihundertsechsundsechzig Quintillionen " +
+				"neunhundertzwanzig Quadrilliarden neunhundertachtunddreißig Quadrillionen vierhundertdreiundsechzig Trilliarden " +
+				"vierhundertdreiundsechzig Trillionen dreihundertvierundsiebzig Billiarden sechshundertsieben Billionen " +
+				"vierhunderteinunddreißig Milliarden siebenhundertachtundsechzig Millionen zweihundertelftausendvierhundertfünfundfünfzig",
+			"trescientos cuarenta sextillones doscientos ochenta y dos mil trescientos sesenta y seis quintillones " +
+				"novecientos veinte mil novecientos treinta y ocho cuatrillones cuatrocientos sesenta y tres mil " +
+				"cuatrocientos sesenta y tres trillones trescientos setenta y cuatro mil seiscientos siete billones " +
+				"cuatrocientos treinta y un mil setecientos sesenta y ocho millones doscientos once mil cuatrocientos cincuenta y cinco",
+		},
+		{"-42", "minus forty-two", "moins quarante-deux", "minus zweiundvierzig", "menos cuarenta y dos"},
+		{"12.05", "twelve point zero five", "douze virgule zéro cinq", "zwölf Komma null fünf", "doce coma cero cinco"},
+		{"12.50", "twelve point five", "douze virgule cinq", "zwölf Komma fünf", "doce coma cinco"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			d := udecimal.MustParse(tc.in)
+
+			for lang, want := range map[Language]string{English: tc.en, French: tc.fr, German: tc.de, Spanish: tc.es} {
+				got, err := Spell(d, Options{Language: lang})
+				require.NoError(t, err)
+				require.Equal(t, want, got, lang)
+			}
+		})
+	}
+}
+
+var (
+	dollar  = Unit{Singular: "dollar", Plural: "dollars", MinorSingular: "cent", MinorPlural: "cents", MinorDigits: 2}
+	euroFR  = Unit{Singular: "euro", Plural: "euros", MinorSingular: "centime", MinorPlural: "centimes", MinorDigits: 2}
+	euroDE  = Unit{Singular: "Euro", Plural: "Euro", MinorSingular: "Cent", MinorPlural: "Cent", MinorDigits: 2}
+	dolarES = Unit{Singular: "dólar", Plural: "dólares", MinorSingular: "centavo", MinorPlural: "centavos", MinorDigits: 2}
+	livreFR = Unit{Singular: "livre", Plural: "livres", MinorSingular: "penny", MinorPlural: "pence", MinorDigits: 2, Feminine: true}
+	kroneDE = Unit{Singular: "Krone", Plural: "Kronen", MinorSingular: "Öre", MinorPlural: "Öre", MinorDigits: 2, Feminine: true}
+	libraES = Unit{Singular: "libra", Plural: "libras", MinorSingular: "penique", MinorPlural: "peniques", MinorDigits: 2, Feminine: true}
+)
+
+func TestSpellAmount(t *testing.T) {
+	testcases := []struct {
+		in   string
+		opts Options
+		want string
+	}{
+		{"1234.56", Options{Unit: dollar, Fraction: FractionNumeric, Capitalize: true}, "One thousand two hundred thirty-four and 56/100 dollars"},
+		{"1234.56", Options{Unit: dollar}, "one thousand two hundred thirty-four dollars and fifty-six cents"},
+		{"1234", Options{Unit: dollar, Fraction: FractionNumeric}, "one thousand two hundred thirty-four and 00/100 dollars"},
+		{"1234.5", Options{Unit: dollar, Fraction: FractionNumeric}, "one thousand two hundred thirty-four and 50/100 dollars"},
+		{"1234", Options{Unit: dollar}, "one thousand two hundred thirty-four dollars"},
+		{"1", Options{Unit: dollar}, "one dollar"},
+		{"1.01", Options{Unit: dollar}, "one dollar and one cent"},
+		{"0", Options{Unit: dollar}, "zero dollars"},
+		{"0.5", Options{Unit: dollar}, "zero dollars and fifty cents"},
+		{"-5.5", Options{Unit: dollar}, "minus five dollars and fifty cents"},
+		{"1000000", Options{Unit: dollar}, "one million dollars"},
+		{"1234", Options{Unit: Unit{Singular: "yen", Plural: "yen"}}, "one thousand two hundred thirty-four yen"},
+		{"12.5", Options{Unit: Unit{Singular: "dollar", Plural: "dollars", MinorDigits: 2}}, "twelve and 50/100 dollars"},
+		{"1234.5", Options{Fraction: FractionNumeric}, "one thousand two hundred thirty-four and 5/10"},
+		{"1234", Options{Fraction: FractionNumeric}, "one thousand two hundred thirty-four"},
+
+		{"1234.56", Options{Language: French, Unit: euroFR}, "mille deux cent trente-quatre euros et cinquante-six centimes"},
+		{"1234.56", Options{Language: French, Unit: euroFR, Fraction: FractionNumeric}, "mille deux cent trente-quatre et 56/100 euros"},
+		{"0", Options{Language: French, Unit: euroFR}, "zéro euro"},
+		{"1.01", Options{Language: French, Unit: euroFR, Capitalize: true}, "Un euro et un centime"},
+		{"21", Options{Language: French, Unit: euroFR}, "vingt et un euros"},
+		{"1000000", Options{Language: French, Unit: euroFR}, "un million d'euros"},
+		{"2000000.5", Options{Language: French, Unit: euroFR}, "deux millions d'euros et cinquante centimes"},
+		{"2000000", Options{Language: French, Unit: livreFR}, "deux millions de livres"},
+		{"2001000", Options{Language: French, Unit: euroFR}, "deux millions mille euros"},
+		{"21.21", Options{Language: French, Unit: livreFR}, "vingt et une livres et vingt et un pence"},
+		{"221201", Options{Language: French, Unit: livreFR}, "deux cent vingt et un mille deux cent une livres"},
+
+		{"1234.56", Options{Language: German, Unit: euroDE}, "eintausendzweihundertvierunddreißig Euro und sechsundfünfzig Cent"},
+		{"1234.56", Options{Language: German, Unit: euroDE, Fraction: FractionNumeric}, "eintausendzweihundertvierunddreißig und 56/100 Euro"},
+		{"1.01", Options{Language: German, Unit: euroDE, Capitalize: true}, "Ein Euro und ein Cent"},
+		{"101", Options{Language: German, Unit: euroDE}, "einhundertein Euro"},
+		{"1", Options{Language: German, Unit: kroneDE}, "eine Krone"},
+		{"0", Options{Language: German, Unit: kroneDE}, "null Kronen"},
+		{"221201", Options{Language: German, Unit: kroneDE}, "zweihunderteinundzwanzigtausendzweihunderteine Kronen"},
+		{"1000000", Options{Language: German, Unit: euroDE}, "eine Million Euro"},
+
+		{"1234.56", Options{Language: Spanish, Unit: dolarES}, "mil doscientos treinta y cuatro dólares con cincuenta y seis centavos"},
+		{"1234.56", Options{Language: Spanish, Unit: dolarES, Fraction: FractionNumeric}, "mil doscientos treinta y cuatro con 56/100 dólares"},
+		{"1", Options{Language: Spanish, Unit: dolarES}, "un dólar"},
+		{"21.21", Options{Language: Spanish, Unit: dolarES}, "veintiún dólares con veintiún centavos"},
+		{"101", Options{Language: Spanish, Unit: dolarES}, "ciento un dólares"},
+		{"1000000", Options{Language: Spanish, Unit: dolarES}, "un millón de dólares"},
+		{"2000000.5", Options{Language: Spanish, Unit: dolarES}, "dos millones de dólares con cincuenta centavos"},
+		{"21", Options{Language: Spanish, Unit: libraES}, "veintiuna libras"},
+		{"1234", Options{Language: Spanish, Unit: libraES}, "mil doscientas treinta y cuatro libras"},
+		{"201000", Options{Language: Spanish, Unit: libraES}, "doscientas un mil libras"},
+		{"221201", Options{Language: Spanish, Unit: libraES}, "doscientas veintiún mil doscientas una libras"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s %s %s", tc.in, tc.opts.Language, tc.opts.Unit.Plural), func(t *testing.T) {
+			got, err := Spell(udecimal.MustParse(tc.in), tc.opts)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSpellErrors(t *testing.T) {
+	_, err := Spell(udecimal.MustParse("1"), Options{Language: Language(10)})
+	require.ErrorIs(t, err, ErrInvalidLanguage)
+
+	_, err = Spell(udecimal.MustParse("1"), Options{Fraction: FractionStyle(10)})
+	require.ErrorIs(t, err, ErrInvalidFractionStyle)
+
+	_, err = Spell(udecimal.MustParse("1.234"), Options{Unit: dollar})
+	require.ErrorIs(t, err, ErrTooManyDigits)
+
+	_, err = Spell(udecimal.MustParse("1.5"), Options{Unit: Unit{Singular: "yen", Plural: "yen"}})
+	require.ErrorIs(t, err, ErrTooManyDigits)
+
+	// trailing zeros are not digits of the amount
+	got, err := Spell(udecimal.MustParse("1.5000"), Options{Unit: dollar})
+	require.NoError(t, err)
+	require.Equal(t, "one dollar and fifty cents", got)
+
+	// the largest integer part
+	_, err = Spell(udecimal.MustParse("999999999999999999999999999999999999999.99"), Options{Unit: dollar})
+	require.NoError(t, err)
+
+	_, err = Spell(udecimal.MustParse("1000000000000000000000000000000000000000"), Options{})
+	require.ErrorIs(t, err, ErrOutOfRange)
+}
+
+func TestLanguageString(t *testing.T) {
+	require.Equal(t, "English", English.String())
+	require.Equal(t, "French", French.String())
+	require.Equal(t, "German", German.String())
+	require.Equal(t, "Spanish", Spanish.String())
+	require.Equal(t, "Language(10)", Language(10).String())
+}